@@ -0,0 +1,241 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kostromin59/funpay"
+)
+
+var (
+	// ErrAccountExists indicates that account with the same name is already added.
+	ErrAccountExists = errors.New("account already exists")
+
+	// ErrAccountNotFound indicates that there is no account with provided name or user id.
+	ErrAccountNotFound = errors.New("account not found")
+)
+
+// Account represents account of [Manager].
+type Account struct {
+	Name string
+
+	// Funpay is the client of the account. Requests made through it respect [Config.RateLimit].
+	Funpay funpay.Funpay
+}
+
+// Health returns the result of the last update of the account (see [funpay.FunpayUpdater.Status]).
+func (a *Account) Health() Health {
+	return Health{
+		Name:     a.Name,
+		UserID:   a.Funpay.UserID(),
+		Username: a.Funpay.Username(),
+		Status:   a.Funpay.Status(),
+	}
+}
+
+// Health represents the account with the result of its last update.
+type Health struct {
+	Name     string
+	UserID   int64
+	Username string
+	funpay.Status
+}
+
+// Healthy reports whether the last update succeeded.
+func (h Health) Healthy() bool {
+	return h.OK()
+}
+
+// Manager holds many accounts keyed by name and keeps their sessions alive.
+type Manager struct {
+	accounts map[string]*Account
+	mu       sync.RWMutex
+}
+
+// New creates empty [Manager].
+func New() *Manager {
+	return &Manager{
+		accounts: make(map[string]*Account),
+	}
+}
+
+// NewFromConfig creates [Manager] with accounts from config file (see [LoadConfig]).
+// Accounts are not updated, call [Manager.Update] to load account info.
+func NewFromConfig(path string) (*Manager, error) {
+	const op = "accounts.NewFromConfig"
+
+	configs, err := LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m := New()
+	for _, cfg := range configs {
+		if _, err := m.Add(cfg); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return m, nil
+}
+
+// Add creates client for config and adds it to the manager. Account is not updated.
+// Returns [ErrInvalidConfig] or [ErrAccountExists].
+func (m *Manager) Add(cfg Config) (*Account, error) {
+	const op = "Manager.Add"
+
+	proxy, err := cfg.proxyURL()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fp := funpay.New(cfg.GoldenKey, cfg.UserAgent)
+	if proxy != nil {
+		fp.SetProxy(proxy)
+	}
+
+	if l := newLimiter(cfg.RateLimit); l != nil {
+		fp.Use(l.middleware())
+	}
+
+	account := &Account{
+		Name:   cfg.Name,
+		Funpay: fp,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.accounts[cfg.Name]; ok {
+		return nil, fmt.Errorf("%s: %w (%s)", op, ErrAccountExists, cfg.Name)
+	}
+
+	m.accounts[cfg.Name] = account
+
+	return account, nil
+}
+
+// Remove removes account from the manager.
+func (m *Manager) Remove(name string) {
+	m.mu.Lock()
+	delete(m.accounts, name)
+	m.mu.Unlock()
+}
+
+// Get returns account by name. Returns [ErrAccountNotFound] if account does not exist.
+func (m *Manager) Get(name string) (*Account, error) {
+	const op = "Manager.Get"
+
+	m.mu.RLock()
+	account, ok := m.accounts[name]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%s: %w (%s)", op, ErrAccountNotFound, name)
+	}
+
+	return account, nil
+}
+
+// ByUserID returns account by user id. User id is known after update of the account.
+// Returns [ErrAccountNotFound] if account does not exist.
+func (m *Manager) ByUserID(userID int64) (*Account, error) {
+	const op = "Manager.ByUserID"
+
+	for _, account := range m.Accounts() {
+		if userID != 0 && account.Funpay.UserID() == userID {
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w (%d)", op, ErrAccountNotFound, userID)
+}
+
+// Accounts returns all accounts sorted by name.
+func (m *Manager) Accounts() []*Account {
+	m.mu.RLock()
+	accounts := make([]*Account, 0, len(m.accounts))
+	for _, account := range m.accounts {
+		accounts = append(accounts, account)
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(accounts, func(a, b *Account) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return accounts
+}
+
+// Update updates all accounts concurrently (see [funpay.FunpayUpdater.Update]). Result is available in [Manager.Health].
+// One unauthorized account does not affect others: the returned error contains errors of failed accounts prefixed by their names.
+func (m *Manager) Update(ctx context.Context) error {
+	const op = "Manager.Update"
+
+	accounts := m.Accounts()
+	errs := make([]error, len(accounts))
+
+	var wg sync.WaitGroup
+	for i, account := range accounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := account.Funpay.Update(ctx); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", account.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Run updates accounts every interval until context is done. Use interval of 40-60 minutes to keep sessions alive.
+// Errors of [Manager.Update] are passed to onError if it is not nil. Returns [funpay.ErrInvalidInterval]
+// if interval is not positive.
+func (m *Manager) Run(ctx context.Context, interval time.Duration, onError func(err error)) error {
+	return funpay.RunEvery(ctx, interval, m.Update, onError)
+}
+
+// Health returns health of all accounts sorted by name.
+func (m *Manager) Health() []Health {
+	accounts := m.Accounts()
+	health := make([]Health, 0, len(accounts))
+	for _, account := range accounts {
+		health = append(health, account.Health())
+	}
+
+	return health
+}
+
+// Healthy reports whether the last update of every account succeeded.
+func (m *Manager) Healthy() bool {
+	for _, health := range m.Health() {
+		if !health.Healthy() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Modules creates module for every account, e.g. Modules(m, lots.New). Key represents account name.
+func Modules[T any](m *Manager, newModule func(fp funpay.Funpay) T) map[string]T {
+	accounts := m.Accounts()
+	modules := make(map[string]T, len(accounts))
+	for _, account := range accounts {
+		modules[account.Name] = newModule(account.Funpay)
+	}
+
+	return modules
+}
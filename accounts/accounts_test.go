@@ -0,0 +1,197 @@
+package accounts_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/accounts"
+	"github.com/kostromin59/funpay/lots"
+)
+
+// newServer returns user id equals golden key. Golden key "bad" is unauthorized.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gk, err := r.Cookie(funpay.CookieGoldenKey)
+		if err != nil || gk.Value == "bad" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, `<html>
+			<body data-app-data='{"userId":%s,"csrf-token":"csrf","locale":"ru"}'>
+				<div class="user-link-name">user%s</div>
+			</body>
+		</html>`, gk.Value, gk.Value)
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestManager_Add(t *testing.T) {
+	t.Parallel()
+
+	m := accounts.New()
+
+	if _, err := m.Add(accounts.Config{Name: "first", GoldenKey: "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := m.Add(accounts.Config{Name: "first", GoldenKey: "2"}); !errors.Is(err, accounts.ErrAccountExists) {
+		t.Errorf("expected ErrAccountExists, got %v", err)
+	}
+
+	invalid := []accounts.Config{
+		{GoldenKey: "1"},
+		{Name: "no key"},
+		{Name: "proxy", GoldenKey: "1", Proxy: "127.0.0.1"},
+		{Name: "rate", GoldenKey: "1", RateLimit: -1},
+	}
+	for _, cfg := range invalid {
+		if _, err := m.Add(cfg); !errors.Is(err, accounts.ErrInvalidConfig) {
+			t.Errorf("%+v: expected ErrInvalidConfig, got %v", cfg, err)
+		}
+	}
+
+	if _, err := m.Get("second"); !errors.Is(err, accounts.ErrAccountNotFound) {
+		t.Errorf("expected ErrAccountNotFound, got %v", err)
+	}
+
+	m.Remove("first")
+	if len(m.Accounts()) != 0 {
+		t.Errorf("expected no accounts, got %d", len(m.Accounts()))
+	}
+}
+
+func TestManager_Update(t *testing.T) {
+	t.Parallel()
+
+	ts := newServer(t)
+
+	m := accounts.New()
+	for _, cfg := range []accounts.Config{
+		{Name: "b", GoldenKey: "2"},
+		{Name: "a", GoldenKey: "1"},
+		{Name: "c", GoldenKey: "bad"},
+	} {
+		account, err := m.Add(cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		account.Funpay.SetBaseURL(ts.URL)
+	}
+
+	if m.Healthy() {
+		t.Error("expected unhealthy manager before update")
+	}
+
+	err := m.Update(t.Context())
+	if !errors.Is(err, funpay.ErrAccountUnauthorized) {
+		t.Fatalf("expected ErrAccountUnauthorized, got %v", err)
+	}
+
+	health := m.Health()
+	if len(health) != 3 {
+		t.Fatalf("expected 3 accounts, got %d", len(health))
+	}
+
+	if health[0].Name != "a" || !health[0].Healthy() || health[0].UserID != 1 || health[0].Username != "user1" {
+		t.Errorf("unexpected health of a: %+v", health[0])
+	}
+
+	if health[2].Name != "c" || health[2].Healthy() {
+		t.Errorf("unexpected health of c: %+v", health[2])
+	}
+
+	if m.Healthy() {
+		t.Error("expected unhealthy manager")
+	}
+
+	account, err := m.ByUserID(2)
+	if err != nil || account.Name != "b" {
+		t.Errorf("expected account b, got %v (%v)", account, err)
+	}
+
+	m.Remove("c")
+	if !m.Healthy() {
+		t.Error("expected healthy manager")
+	}
+
+	modules := accounts.Modules(m, lots.New)
+	if len(modules) != 2 || modules["a"] == nil || modules["b"] == nil {
+		t.Errorf("unexpected modules: %v", modules)
+	}
+}
+
+func TestManager_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	ts := newServer(t)
+
+	m := accounts.New()
+	account, err := m.Add(accounts.Config{Name: "a", GoldenKey: "1", RateLimit: 20})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	account.Funpay.SetBaseURL(ts.URL)
+
+	// Every method making requests is limited: the limiter is a middleware of the client.
+	start := time.Now()
+	if err := account.Funpay.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := account.Funpay.RefreshProfile(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := account.Funpay.RequestHTML(t.Context(), ts.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected requests to be limited, took %s", elapsed)
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	config := `[
+		{"name": "shop", "golden_key": "1", "user_agent": "ua", "proxy": "http://127.0.0.1:8080", "rate_limit": 2},
+		{"name": "other", "golden_key": "2"}
+	]`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := accounts.NewFromConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	account, err := m.Get("shop")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if account.Funpay.GoldenKey() != "1" || account.Funpay.UserAgent() != "ua" {
+		t.Errorf("unexpected account: %s %s", account.Funpay.GoldenKey(), account.Funpay.UserAgent())
+	}
+
+	if len(m.Accounts()) != 2 {
+		t.Errorf("expected 2 accounts, got %d", len(m.Accounts()))
+	}
+}
@@ -0,0 +1,71 @@
+package accounts
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kostromin59/funpay"
+)
+
+// limiter spaces calls of wait by interval.
+type limiter struct {
+	interval time.Duration
+	next     time.Time
+	mu       sync.Mutex
+}
+
+func newLimiter(rate float64) *limiter {
+	if rate <= 0 {
+		return nil
+	}
+
+	return &limiter{
+		interval: time.Duration(float64(time.Second) / rate),
+	}
+}
+
+// wait blocks until the next request is allowed or context is done.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// middleware returns [funpay.Middleware] which waits for the limiter before every request.
+func (l *limiter) middleware() funpay.Middleware {
+	return func(next funpay.Doer) funpay.Doer {
+		return funpay.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if err := l.wait(req.Context()); err != nil {
+				return nil, err
+			}
+
+			return next.Do(req)
+		})
+	}
+}
@@ -1,8 +1,54 @@
 package funpay
 
+import "encoding/json"
+
 // AppData represents the object from data-app-data attribute inside the body element.
 type AppData struct {
-	CSRFToken string `json:"csrf-token"`
-	UserID    int64  `json:"userId"`
-	Locale    Locale `json:"locale"`
+	CSRFToken string  `json:"csrf-token"`
+	UserID    int64   `json:"userId"`
+	Locale    Locale  `json:"locale"`
+	Webpush   Webpush `json:"webpush"`
+
+	// Currency and Timezone are filled only if they are present as strings.
+	Currency Currency `json:"-"`
+	Timezone string   `json:"-"`
+
+	// Raw contains all fields of the object including unknown ones.
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// Webpush represents push notifications settings from [AppData].
+type Webpush struct {
+	App          string `json:"app"`
+	Enabled      bool   `json:"enabled"`
+	HWIDRequired bool   `json:"hwid-required"`
+}
+
+func (a *AppData) UnmarshalJSON(data []byte) error {
+	// appData has no UnmarshalJSON method, so the default decoding is used.
+	type appData AppData
+	var parsed appData
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, &parsed.Raw); err != nil {
+		return err
+	}
+
+	*a = AppData(parsed)
+	a.Currency = Currency(rawString(a.Raw["currency"]))
+	a.Timezone = rawString(a.Raw["timezone"])
+
+	return nil
+}
+
+// rawString returns JSON string value or empty string if value is not a string.
+func rawString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return ""
+	}
+
+	return s
 }
@@ -0,0 +1,388 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/lots"
+)
+
+var (
+	// ErrGameNotFound indicates that game is not loaded into catalog (see [Catalog.Update]).
+	ErrGameNotFound = errors.New("game not found")
+)
+
+// GameID represents ID of game (data-id attribute on the main page).
+type GameID string
+
+// Names represents localized names. Key is [funpay.Locale].
+type Names map[funpay.Locale]string
+
+// Name returns name for provided locale. Fallbacks to any other known locale if there is no such name.
+func (n Names) Name(locale funpay.Locale) string {
+	if name, ok := n[locale]; ok {
+		return name
+	}
+
+	for _, l := range []funpay.Locale{funpay.LocaleRU, funpay.LocaleEN} {
+		if name, ok := n[l]; ok {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// Game represents game with its categories (nodes).
+type Game struct {
+	ID    GameID
+	Names Names
+	Nodes []Node
+}
+
+// Node represents category of the game.
+type Node struct {
+	ID     lots.NodeID
+	GameID GameID
+	Names  Names
+
+	// Chips reports whether node is a currency category (/chips/ instead of /lots/).
+	Chips bool
+}
+
+//go:generate go tool mockgen -destination ../mocks/catalog.go -package mocks . Catalog
+type Catalog interface {
+	// Update loads games and their nodes from the main page. Names are saved for current account locale.
+	// Use [Catalog.UpdateLocale] to load names for another locale.
+	Update(ctx context.Context) error
+
+	// UpdateLocale loads games and their nodes from the main page of provided locale (see [funpay.RequestWithLocale]).
+	// Account locale is not changed.
+	UpdateLocale(ctx context.Context, locale funpay.Locale) error
+
+	// UpdateGame loads all nodes of the game from the page of its first node.
+	// Main page does not contain every category of the game.
+	//
+	// Returns [ErrGameNotFound] if game is not loaded.
+	UpdateGame(ctx context.Context, gameID GameID) error
+
+	// Games returns loaded games in the order of the main page.
+	Games() []Game
+
+	// Game returns loaded game by id.
+	Game(gameID GameID) (Game, bool)
+
+	// Node returns loaded node by id.
+	Node(nodeID lots.NodeID) (Node, bool)
+
+	// Schema returns fields of the node (see [lots.Lots.FieldsByNodeID]).
+	// Fields are loaded once and cached, returned value is a copy.
+	Schema(ctx context.Context, nodeID lots.NodeID) (lots.Fields, error)
+
+	// Find searches loaded nodes by game and node names in every loaded locale.
+	// Matching is case-insensitive and tolerates typos, prefixes and acronyms ("bo6" for "Black Ops 6").
+	// Names of [Match] are returned in provided locale. Matches are sorted by score, best first.
+	Find(query string, locale funpay.Locale) []Match
+}
+
+type CatalogClient struct {
+	fp   funpay.Funpay
+	lots lots.Lots
+
+	games   map[GameID]*Game
+	order   []GameID
+	schemas map[lots.NodeID]lots.Fields
+	mu      sync.RWMutex
+}
+
+func New(fp funpay.Funpay) Catalog {
+	return &CatalogClient{
+		fp:      fp,
+		lots:    lots.New(fp),
+		games:   make(map[GameID]*Game),
+		schemas: make(map[lots.NodeID]lots.Fields),
+	}
+}
+
+func (c *CatalogClient) Update(ctx context.Context) error {
+	const op = "CatalogClient.Update"
+
+	doc, err := c.fp.RequestHTML(ctx, c.fp.BaseURL())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Account locale is known after the request.
+	c.mergeGames(extractGames(doc), c.locale())
+
+	return nil
+}
+
+func (c *CatalogClient) UpdateLocale(ctx context.Context, locale funpay.Locale) error {
+	const op = "CatalogClient.UpdateLocale"
+
+	doc, err := c.fp.RequestHTML(ctx, c.fp.BaseURL(), funpay.RequestWithLocale(locale))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	c.mergeGames(extractGames(doc), locale)
+
+	return nil
+}
+
+func (c *CatalogClient) mergeGames(games []parsedGame, locale funpay.Locale) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, game := range games {
+		stored, ok := c.games[game.id]
+		if !ok {
+			stored = &Game{ID: game.id, Names: make(Names)}
+			c.games[game.id] = stored
+			c.order = append(c.order, game.id)
+		}
+
+		stored.Names[locale] = game.name
+		for _, node := range game.nodes {
+			c.mergeNode(stored, node, locale)
+		}
+	}
+}
+
+func (c *CatalogClient) UpdateGame(ctx context.Context, gameID GameID) error {
+	const op = "CatalogClient.UpdateGame"
+
+	game, ok := c.Game(gameID)
+	if !ok || len(game.Nodes) == 0 {
+		return fmt.Errorf("%s: %w (%s)", op, ErrGameNotFound, gameID)
+	}
+
+	first := game.Nodes[0]
+	reqURL, err := funpay.URL(nodeSection(first.Chips), string(first.ID), "/").WithBaseURL(c.fp.BaseURL()).Parse()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := c.fp.RequestHTML(ctx, reqURL.String())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	nodes := extractCounterNodes(doc)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored, ok := c.games[gameID]
+	if !ok {
+		return fmt.Errorf("%s: %w (%s)", op, ErrGameNotFound, gameID)
+	}
+
+	locale := c.locale()
+	for _, node := range nodes {
+		c.mergeNode(stored, node, locale)
+	}
+
+	return nil
+}
+
+func (c *CatalogClient) Games() []Game {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	games := make([]Game, 0, len(c.order))
+	for _, id := range c.order {
+		games = append(games, cloneGame(c.games[id]))
+	}
+
+	return games
+}
+
+func (c *CatalogClient) Game(gameID GameID) (Game, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	game, ok := c.games[gameID]
+	if !ok {
+		return Game{}, false
+	}
+
+	return cloneGame(game), true
+}
+
+func (c *CatalogClient) Node(nodeID lots.NodeID) (Node, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, id := range c.order {
+		for _, node := range c.games[id].Nodes {
+			if node.ID == nodeID {
+				node.Names = maps.Clone(node.Names)
+				return node, true
+			}
+		}
+	}
+
+	return Node{}, false
+}
+
+func (c *CatalogClient) Schema(ctx context.Context, nodeID lots.NodeID) (lots.Fields, error) {
+	const op = "CatalogClient.Schema"
+
+	c.mu.RLock()
+	fields, ok := c.schemas[nodeID]
+	c.mu.RUnlock()
+
+	if ok {
+		return maps.Clone(fields), nil
+	}
+
+	fields, err := c.lots.FieldsByNodeID(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c.mu.Lock()
+	c.schemas[nodeID] = fields
+	c.mu.Unlock()
+
+	return maps.Clone(fields), nil
+}
+
+// locale returns account locale. Defaults to [funpay.LocaleRU] if account is not updated yet.
+func (c *CatalogClient) locale() funpay.Locale {
+	locale := c.fp.Locale()
+	if locale == "" {
+		return funpay.LocaleRU
+	}
+
+	return locale
+}
+
+func (c *CatalogClient) mergeNode(game *Game, node parsedNode, locale funpay.Locale) {
+	for i := range game.Nodes {
+		if game.Nodes[i].ID == node.id {
+			game.Nodes[i].Names[locale] = node.name
+			return
+		}
+	}
+
+	game.Nodes = append(game.Nodes, Node{
+		ID:     node.id,
+		GameID: game.ID,
+		Names:  Names{locale: node.name},
+		Chips:  node.chips,
+	})
+}
+
+func cloneGame(game *Game) Game {
+	clone := Game{
+		ID:    game.ID,
+		Names: maps.Clone(game.Names),
+		Nodes: slices.Clone(game.Nodes),
+	}
+
+	for i := range clone.Nodes {
+		clone.Nodes[i].Names = maps.Clone(clone.Nodes[i].Names)
+	}
+
+	return clone
+}
+
+type parsedGame struct {
+	id    GameID
+	name  string
+	nodes []parsedNode
+}
+
+type parsedNode struct {
+	id    lots.NodeID
+	name  string
+	chips bool
+}
+
+// extractGames parses .promo-game-item elements of the main page.
+func extractGames(doc *goquery.Document) []parsedGame {
+	var games []parsedGame
+	for _, item := range doc.Find(".promo-game-item").EachIter() {
+		for _, title := range item.Find(".game-title[data-id]").EachIter() {
+			id := title.AttrOr("data-id", "")
+			if id == "" {
+				continue
+			}
+
+			game := parsedGame{
+				id:   GameID(id),
+				name: strings.TrimSpace(title.Text()),
+			}
+
+			list := item.Find(fmt.Sprintf("ul.list-inline[data-id=%q]", id))
+			if list.Length() == 0 {
+				list = item.Find("ul.list-inline").First()
+			}
+
+			for _, link := range list.Find("a[href]").EachIter() {
+				node, ok := parseNodeLink(link.AttrOr("href", ""), link.Text())
+				if !ok {
+					continue
+				}
+
+				game.nodes = append(game.nodes, node)
+			}
+
+			games = append(games, game)
+		}
+	}
+
+	return games
+}
+
+// extractCounterNodes parses list of game categories on the node page.
+func extractCounterNodes(doc *goquery.Document) []parsedNode {
+	var nodes []parsedNode
+	for _, link := range doc.Find(".counter-list a.counter-item[href]").EachIter() {
+		name := link.Find(".counter-param").Text()
+		if name == "" {
+			name = link.Text()
+		}
+
+		node, ok := parseNodeLink(link.AttrOr("href", ""), name)
+		if !ok {
+			continue
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// parseNodeLink parses node link with its name (see [lots.ParseNodeLink]).
+func parseNodeLink(href, name string) (parsedNode, bool) {
+	id, chips, ok := lots.ParseNodeLink(href)
+	if !ok {
+		return parsedNode{}, false
+	}
+
+	return parsedNode{
+		id:    id,
+		name:  strings.TrimSpace(name),
+		chips: chips,
+	}, true
+}
+
+func nodeSection(chips bool) string {
+	if chips {
+		return "chips"
+	}
+
+	return "lots"
+}
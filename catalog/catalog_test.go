@@ -0,0 +1,254 @@
+package catalog_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/catalog"
+	"github.com/kostromin59/funpay/lots"
+	"github.com/kostromin59/funpay/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+const mainPageRU = `<html>
+	<body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="40"><a href="https://funpay.com/lots/111/">Call of Duty: Black Ops 6</a></div>
+			<ul class="list-inline" data-id="40">
+				<li><a href="https://funpay.com/lots/2852/">Аккаунты</a></li>
+				<li><a href="https://funpay.com/lots/111/">Услуги</a></li>
+			</ul>
+		</div>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="2"><a href="https://funpay.com/chips/2/">World of Warcraft</a></div>
+			<ul class="list-inline" data-id="2">
+				<li><a href="https://funpay.com/chips/2/">Золото</a></li>
+				<li><a href="https://funpay.com/users/1/">Not a node</a></li>
+			</ul>
+		</div>
+	</body>
+</html>`
+
+const mainPageEN = `<html>
+	<body data-app-data='{"userId":123,"csrf-token":"test","locale":"en"}'>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="40"><a href="https://funpay.com/en/lots/111/">Call of Duty: Black Ops 6</a></div>
+			<ul class="list-inline" data-id="40">
+				<li><a href="https://funpay.com/en/lots/2852/">Accounts</a></li>
+				<li><a href="https://funpay.com/en/lots/111/">Services</a></li>
+			</ul>
+		</div>
+	</body>
+</html>`
+
+func TestCatalog_Update(t *testing.T) {
+	t.Parallel()
+	t.Run("successful update with locales", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		c := catalog.New(fp)
+
+		docRU, err := goquery.NewDocumentFromReader(strings.NewReader(mainPageRU))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		docEN, err := goquery.NewDocumentFromReader(strings.NewReader(mainPageEN))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+		gomock.InOrder(
+			fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com").Times(1).Return(docRU, nil),
+			fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com", gomock.Any()).Times(1).Return(docEN, nil),
+		)
+		fp.EXPECT().Locale().Times(1).Return(funpay.LocaleRU)
+
+		if err := c.Update(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Account locale is not read: names are saved for requested locale.
+		if err := c.UpdateLocale(t.Context(), funpay.LocaleEN); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []catalog.Game{
+			{
+				ID:    "40",
+				Names: catalog.Names{funpay.LocaleRU: "Call of Duty: Black Ops 6", funpay.LocaleEN: "Call of Duty: Black Ops 6"},
+				Nodes: []catalog.Node{
+					{ID: "2852", GameID: "40", Names: catalog.Names{funpay.LocaleRU: "Аккаунты", funpay.LocaleEN: "Accounts"}},
+					{ID: "111", GameID: "40", Names: catalog.Names{funpay.LocaleRU: "Услуги", funpay.LocaleEN: "Services"}},
+				},
+			},
+			{
+				ID:    "2",
+				Names: catalog.Names{funpay.LocaleRU: "World of Warcraft"},
+				Nodes: []catalog.Node{
+					{ID: "2", GameID: "2", Names: catalog.Names{funpay.LocaleRU: "Золото"}, Chips: true},
+				},
+			},
+		}
+
+		if games := c.Games(); !reflect.DeepEqual(games, expected) {
+			t.Errorf("expected %+v, got %+v", expected, games)
+		}
+
+		node, ok := c.Node("2852")
+		if !ok {
+			t.Fatal("expected node to be found")
+		}
+
+		if name := node.Names.Name(funpay.LocaleEN); name != "Accounts" {
+			t.Errorf("expected %q, got %q", "Accounts", name)
+		}
+
+		if name := c.Games()[1].Nodes[0].Names.Name(funpay.LocaleEN); name != "Золото" {
+			t.Errorf("expected fallback name %q, got %q", "Золото", name)
+		}
+	})
+
+	t.Run("unauthorized request", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		c := catalog.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com").Times(1).Return(nil, funpay.ErrAccountUnauthorized)
+
+		if err := c.Update(t.Context()); !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Fatalf("expected ErrAccountUnauthorized, got %v", err)
+		}
+	})
+}
+
+func TestCatalog_UpdateGame(t *testing.T) {
+	t.Parallel()
+	t.Run("loads all nodes of the game", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		c := catalog.New(fp)
+
+		mainDoc, err := goquery.NewDocumentFromReader(strings.NewReader(mainPageRU))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		gameDoc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+			<body>
+				<div class="counter-list">
+					<a class="counter-item" href="https://funpay.com/lots/2852/"><div class="counter-param">Аккаунты</div></a>
+					<a class="counter-item" href="https://funpay.com/lots/3000/"><div class="counter-param">Предметы</div></a>
+				</div>
+			</body>
+		</html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+		fp.EXPECT().Locale().Times(2).Return(funpay.LocaleRU)
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com").Times(1).Return(mainDoc, nil)
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/lots/2852/").Times(1).Return(gameDoc, nil)
+
+		if err := c.Update(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := c.UpdateGame(t.Context(), "40"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		game, ok := c.Game("40")
+		if !ok {
+			t.Fatal("expected game to be found")
+		}
+
+		ids := make([]lots.NodeID, 0, len(game.Nodes))
+		for _, node := range game.Nodes {
+			ids = append(ids, node.ID)
+		}
+
+		if expected := []lots.NodeID{"2852", "111", "3000"}; !reflect.DeepEqual(ids, expected) {
+			t.Errorf("expected %v, got %v", expected, ids)
+		}
+	})
+
+	t.Run("unknown game", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		c := catalog.New(mocks.NewMockFunpay(ctrl))
+
+		if err := c.UpdateGame(t.Context(), "1"); !errors.Is(err, catalog.ErrGameNotFound) {
+			t.Fatalf("expected ErrGameNotFound, got %v", err)
+		}
+	})
+}
+
+func TestCatalog_Schema(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fp := mocks.NewMockFunpay(ctrl)
+	c := catalog.New(fp)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+		<body>
+			<form>
+				<input type="hidden" name="offer_id" value="0">
+				<input type="hidden" name="node_id" value="2852">
+			</form>
+		</body>
+	</html>`))
+	if err != nil {
+		t.Fatal("invalid doc provided")
+	}
+
+	fp.EXPECT().Tracer().AnyTimes().Return(funpay.NoopTracer{})
+	fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+	fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/lots/offerEdit?node=2852").Times(1).Return(doc, nil)
+
+	fields, err := c.Schema(t.Context(), "2852")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fields["offer_id"] = lots.Field{Value: "changed"}
+
+	cached, err := c.Schema(t.Context(), "2852")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := lots.Fields{
+		"offer_id": lots.Field{Value: "0"},
+		"node_id":  lots.Field{Value: "2852"},
+	}
+
+	if !reflect.DeepEqual(cached, expected) {
+		t.Errorf("expected %+v, got %+v", expected, cached)
+	}
+}
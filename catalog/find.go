@@ -0,0 +1,196 @@
+package catalog
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/lots"
+)
+
+// minMatchScore is a minimal score of [Match] returned by [Catalog.Find].
+const minMatchScore = 0.5
+
+// Match represents node found by [Catalog.Find].
+type Match struct {
+	NodeID lots.NodeID
+	GameID GameID
+
+	// Game and Node are names in the requested locale (see [Names.Name]).
+	Game string
+	Node string
+
+	// Score is in range (0, 1], where 1 means that every word is matched exactly.
+	Score float64
+}
+
+func (c *CatalogClient) Find(query string, locale funpay.Locale) []Match {
+	queryTokens := tokenize(query)
+	if len(queryTokens) == 0 {
+		return nil
+	}
+
+	var matches []Match
+	for _, game := range c.Games() {
+		for _, node := range game.Nodes {
+			var best float64
+			for l := range node.Names {
+				score := matchScore(queryTokens, tokenize(game.Names.Name(l)+" "+node.Names[l]))
+				best = max(best, score)
+			}
+
+			if best < minMatchScore {
+				continue
+			}
+
+			matches = append(matches, Match{
+				NodeID: node.ID,
+				GameID: game.ID,
+				Game:   game.Names.Name(locale),
+				Node:   node.Names.Name(locale),
+				Score:  best,
+			})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b Match) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	return matches
+}
+
+// matchScore rates how well query matches the name. Every query token is compared with
+// name tokens: exact match, acronym of consecutive words ("bo6" for "black ops 6"),
+// prefix and typos are accepted with decreasing weights. Names with less unmatched words rank higher.
+func matchScore(query, name []string) float64 {
+	if len(name) == 0 {
+		return 0
+	}
+
+	matched := make([]bool, len(name))
+
+	var total float64
+	for _, token := range query {
+		var best float64
+		var bestFrom, bestTo int
+		for i := range name {
+			for j := i + 1; j <= len(name); j++ {
+				score := tokenScore(token, name[i:j])
+				if score > best {
+					best, bestFrom, bestTo = score, i, j
+				}
+			}
+		}
+
+		if best == 0 {
+			continue
+		}
+
+		total += best
+		for i := bestFrom; i < bestTo; i++ {
+			matched[i] = true
+		}
+	}
+
+	var coverage float64
+	for _, ok := range matched {
+		if ok {
+			coverage++
+		}
+	}
+
+	return 0.8*total/float64(len(query)) + 0.2*coverage/float64(len(name))
+}
+
+// tokenScore compares query token with sequence of name words.
+// Sequences longer than one word are matched only as acronym.
+func tokenScore(token string, words []string) float64 {
+	if len(words) > 1 {
+		if token == acronym(words) {
+			return 0.9
+		}
+
+		return 0
+	}
+
+	word := words[0]
+	switch {
+	case token == word:
+		return 1
+	case len([]rune(token)) >= 2 && strings.HasPrefix(word, token):
+		return 0.8
+	case len([]rune(word)) >= 3 && strings.HasPrefix(token, word):
+		return 0.7
+	}
+
+	tokenLen := len([]rune(token))
+	if tokenLen < 4 {
+		return 0
+	}
+
+	allowed := 1
+	if tokenLen >= 7 {
+		allowed = 2
+	}
+
+	if levenshtein(token, word) <= allowed {
+		return 0.6
+	}
+
+	return 0
+}
+
+// acronym returns first letters of words. Numbers are kept as is ("black ops 6" -> "bo6").
+func acronym(words []string) string {
+	var b strings.Builder
+	for _, word := range words {
+		r := []rune(word)
+		if unicode.IsDigit(r[0]) {
+			b.WriteString(word)
+			continue
+		}
+
+		b.WriteRune(r[0])
+	}
+
+	return b.String()
+}
+
+// tokenize splits text into lower-cased words without punctuation.
+func tokenize(text string) []string {
+	text = strings.ToLower(text)
+	text = strings.ReplaceAll(text, "ё", "е")
+
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,173 @@
+package catalog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/catalog"
+	"github.com/kostromin59/funpay/lots"
+	"github.com/kostromin59/funpay/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+const findPageRU = `<html>
+	<body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="40"><a href="https://funpay.com/lots/111/">Call of Duty: Black Ops 6</a></div>
+			<ul class="list-inline" data-id="40">
+				<li><a href="https://funpay.com/lots/2852/">Аккаунты</a></li>
+				<li><a href="https://funpay.com/lots/111/">Услуги</a></li>
+			</ul>
+		</div>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="41"><a href="https://funpay.com/lots/500/">Call of Duty: Warzone</a></div>
+			<ul class="list-inline" data-id="41">
+				<li><a href="https://funpay.com/lots/500/">Аккаунты</a></li>
+			</ul>
+		</div>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="2"><a href="https://funpay.com/chips/2/">World of Warcraft</a></div>
+			<ul class="list-inline" data-id="2">
+				<li><a href="https://funpay.com/chips/2/">Золото</a></li>
+			</ul>
+		</div>
+	</body>
+</html>`
+
+const findPageEN = `<html>
+	<body data-app-data='{"userId":123,"csrf-token":"test","locale":"en"}'>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="40"><a href="https://funpay.com/en/lots/111/">Call of Duty: Black Ops 6</a></div>
+			<ul class="list-inline" data-id="40">
+				<li><a href="https://funpay.com/en/lots/2852/">Accounts</a></li>
+				<li><a href="https://funpay.com/en/lots/111/">Services</a></li>
+			</ul>
+		</div>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="41"><a href="https://funpay.com/en/lots/500/">Call of Duty: Warzone</a></div>
+			<ul class="list-inline" data-id="41">
+				<li><a href="https://funpay.com/en/lots/500/">Accounts</a></li>
+			</ul>
+		</div>
+		<div class="promo-game-item">
+			<div class="game-title" data-id="2"><a href="https://funpay.com/en/chips/2/">World of Warcraft</a></div>
+			<ul class="list-inline" data-id="2">
+				<li><a href="https://funpay.com/en/chips/2/">Gold</a></li>
+			</ul>
+		</div>
+	</body>
+</html>`
+
+func TestCatalog_Find(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fp := mocks.NewMockFunpay(ctrl)
+	c := catalog.New(fp)
+
+	docRU, err := goquery.NewDocumentFromReader(strings.NewReader(findPageRU))
+	if err != nil {
+		t.Fatal("invalid doc provided")
+	}
+
+	docEN, err := goquery.NewDocumentFromReader(strings.NewReader(findPageEN))
+	if err != nil {
+		t.Fatal("invalid doc provided")
+	}
+
+	fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+	gomock.InOrder(
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com").Times(1).Return(docRU, nil),
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com").Times(1).Return(docEN, nil),
+	)
+	gomock.InOrder(
+		fp.EXPECT().Locale().Times(1).Return(funpay.LocaleRU),
+		fp.EXPECT().Locale().Times(1).Return(funpay.LocaleEN),
+	)
+
+	if err := c.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		locale   funpay.Locale
+		expected lots.NodeID
+		node     string
+	}{
+		{
+			name:     "acronym",
+			query:    "Call of Duty BO6 accounts",
+			locale:   funpay.LocaleEN,
+			expected: "2852",
+			node:     "Accounts",
+		},
+		{
+			name:     "russian name with english result",
+			query:    "black ops 6 аккаунты",
+			locale:   funpay.LocaleEN,
+			expected: "2852",
+			node:     "Accounts",
+		},
+		{
+			name:     "typo and case",
+			query:    "WARZNE akkounts accaunts",
+			locale:   funpay.LocaleRU,
+			expected: "500",
+			node:     "Аккаунты",
+		},
+		{
+			name:     "prefix",
+			query:    "wow gol",
+			locale:   funpay.LocaleRU,
+			expected: "2",
+			node:     "Золото",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matches := c.Find(tt.query, tt.locale)
+			if len(matches) == 0 {
+				t.Fatalf("expected matches for %q", tt.query)
+			}
+
+			if matches[0].NodeID != tt.expected {
+				t.Errorf("expected node %q, got %+v", tt.expected, matches)
+			}
+
+			if matches[0].Node != tt.node {
+				t.Errorf("expected node name %q, got %q", tt.node, matches[0].Node)
+			}
+
+			for i := 1; i < len(matches); i++ {
+				if matches[i].Score > matches[i-1].Score {
+					t.Errorf("expected matches sorted by score, got %+v", matches)
+				}
+			}
+		})
+	}
+
+	t.Run("nothing found", func(t *testing.T) {
+		t.Parallel()
+
+		if matches := c.Find("minecraft", funpay.LocaleEN); len(matches) != 0 {
+			t.Errorf("expected no matches, got %+v", matches)
+		}
+
+		if matches := c.Find("  ", funpay.LocaleEN); len(matches) != 0 {
+			t.Errorf("expected no matches for empty query, got %+v", matches)
+		}
+	})
+}
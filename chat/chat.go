@@ -0,0 +1,270 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+)
+
+var (
+	// ErrSendFailed indicates that Funpay rejected the message (e.g. chat is blocked or text is too long).
+	ErrSendFailed = errors.New("send failed")
+)
+
+// ChatID represents chat node: numeric ID or name (e.g. users-1-2).
+type ChatID string
+
+// PrivateChatID returns [ChatID] of private chat between two users.
+func PrivateChatID(userID, otherID int64) ChatID {
+	return ChatID(fmt.Sprintf("users-%d-%d", min(userID, otherID), max(userID, otherID)))
+}
+
+// ChatType represents type of the chat.
+type ChatType string
+
+const (
+	// ChatTypePrivate is a chat between two users.
+	ChatTypePrivate ChatType = "private"
+	// ChatTypePublic is a common chat (e.g. flood-ru).
+	ChatTypePublic ChatType = "public"
+)
+
+// Type detects type of the chat. Numeric IDs and users-* names are private.
+func (id ChatID) Type() ChatType {
+	if strings.HasPrefix(string(id), "users-") {
+		return ChatTypePrivate
+	}
+
+	if _, err := strconv.ParseInt(string(id), 10, 64); err == nil {
+		return ChatTypePrivate
+	}
+
+	return ChatTypePublic
+}
+
+// Contact represents chat from the contact list (/chat/ page).
+type Contact struct {
+	ID            ChatID
+	Username      string
+	LastMessage   string
+	LastMessageID int64
+	Unread        bool
+}
+
+// Message represents chat message. AuthorID equals 0 for system messages.
+type Message struct {
+	ID       int64
+	AuthorID int64
+	Author   string
+	Text     string
+}
+
+//go:generate go tool mockgen -destination ../mocks/chat.go -package mocks . Chat
+type Chat interface {
+	// Send sends text message into the chat using /runner/ endpoint.
+	// Returns [ErrSendFailed] if Funpay responded with error.
+	Send(ctx context.Context, chatID ChatID, text string) error
+
+	// Chats loads contact list from /chat/ page.
+	Chats(ctx context.Context) ([]Contact, error)
+
+	// History loads the last messages of the chat, oldest first.
+	History(ctx context.Context, chatID ChatID) ([]Message, error)
+}
+
+type ChatClient struct {
+	fp funpay.Funpay
+}
+
+func New(fp funpay.Funpay) Chat {
+	return &ChatClient{
+		fp: fp,
+	}
+}
+
+// runnerRequest represents request param of /runner/ endpoint.
+type runnerRequest struct {
+	Action string `json:"action"`
+	Data   any    `json:"data"`
+}
+
+type runnerMessage struct {
+	Node        ChatID `json:"node"`
+	LastMessage int64  `json:"last_message"`
+	Content     string `json:"content"`
+}
+
+// runnerResponse represents response of /runner/ endpoint.
+// Error is either null, false or text of the error.
+type runnerResponse struct {
+	Response struct {
+		Error any `json:"error"`
+	} `json:"response"`
+}
+
+func (r runnerResponse) err() string {
+	switch e := r.Response.Error.(type) {
+	case string:
+		return e
+	case bool:
+		if e {
+			return "unknown error"
+		}
+	}
+
+	return ""
+}
+
+func (c *ChatClient) Send(ctx context.Context, chatID ChatID, text string) error {
+	const op = "ChatClient.Send"
+
+	request, err := json.Marshal(runnerRequest{
+		Action: "chat_message",
+		Data: runnerMessage{
+			Node:        chatID,
+			LastMessage: -1,
+			Content:     text,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	body := url.Values{}
+	body.Set("objects", "[]")
+	body.Set("request", string(request))
+	body.Set(funpay.FormCSRFToken, c.fp.CSRFToken())
+
+	resp, err := c.fp.Request(ctx, funpay.URL("runner", "/").WithBaseURL(c.fp.BaseURL()).String(),
+		funpay.RequestWithMethod(http.MethodPost),
+		funpay.RequestWithBody(bytes.NewBufferString(body.Encode())),
+		funpay.RequestWithHeaders(funpay.RequestPostHeaders),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	var runnerResp runnerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&runnerResp); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if msg := runnerResp.err(); msg != "" {
+		return fmt.Errorf("%s: %w (%s)", op, ErrSendFailed, msg)
+	}
+
+	return nil
+}
+
+func (c *ChatClient) Chats(ctx context.Context) ([]Contact, error) {
+	const op = "ChatClient.Chats"
+
+	reqURL, err := funpay.URL("chat", "/").WithBaseURL(c.fp.BaseURL()).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := c.fp.RequestHTML(ctx, reqURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return extractContacts(doc), nil
+}
+
+func extractContacts(doc *goquery.Document) []Contact {
+	items := doc.Find("a.contact-item")
+	contacts := make([]Contact, 0, items.Length())
+	for _, item := range items.EachIter() {
+		id := item.AttrOr("data-id", "")
+		if href, ok := item.Attr("href"); ok {
+			if u, err := url.Parse(href); err == nil && u.Query().Get("node") != "" {
+				id = u.Query().Get("node")
+			}
+		}
+
+		if id == "" {
+			continue
+		}
+
+		lastMessageID, _ := strconv.ParseInt(item.AttrOr("data-node-msg", ""), 10, 64)
+
+		contacts = append(contacts, Contact{
+			ID:            ChatID(id),
+			Username:      strings.TrimSpace(item.Find(".media-user-name").Text()),
+			LastMessage:   strings.TrimSpace(item.Find(".contact-item-message").Text()),
+			LastMessageID: lastMessageID,
+			Unread:        item.HasClass("unread"),
+		})
+	}
+
+	return contacts
+}
+
+// historyResponse represents response of /chat/history endpoint.
+type historyResponse struct {
+	Chat struct {
+		Messages []struct {
+			ID     int64  `json:"id"`
+			Author int64  `json:"author"`
+			HTML   string `json:"html"`
+		} `json:"messages"`
+	} `json:"chat"`
+}
+
+func (c *ChatClient) History(ctx context.Context, chatID ChatID) ([]Message, error) {
+	const op = "ChatClient.History"
+
+	reqURL, err := funpay.URL("chat", "history").
+		WithBaseURL(c.fp.BaseURL()).
+		WithQuery("node", string(chatID)).
+		WithQuery("last_message", "0").
+		Parse()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// History is loaded with GET request, only XHR header is needed to get JSON instead of the page.
+	resp, err := c.fp.Request(ctx, reqURL.String(), funpay.RequestWithHeaders(map[string]string{
+		"x-requested-with": "XMLHttpRequest",
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	var history historyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	messages := make([]Message, 0, len(history.Chat.Messages))
+	for _, m := range history.Chat.Messages {
+		msg := Message{
+			ID:       m.ID,
+			AuthorID: m.Author,
+		}
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(m.HTML))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		msg.Text = strings.TrimSpace(doc.Find(".chat-msg-text").Text())
+		msg.Author = strings.TrimSpace(doc.Find(".chat-msg-author-link").First().Text())
+
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
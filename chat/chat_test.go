@@ -0,0 +1,181 @@
+package chat_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay/chat"
+	"github.com/kostromin59/funpay/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPrivateChatID(t *testing.T) {
+	t.Parallel()
+
+	if id := chat.PrivateChatID(20, 10); id != "users-10-20" {
+		t.Errorf("expected %q, got %q", "users-10-20", id)
+	}
+}
+
+func TestChat_Send(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		response string
+		err      error
+	}{
+		{
+			name:     "successful send",
+			response: `{"objects":[],"response":{"error":null}}`,
+		},
+		{
+			name:     "funpay error",
+			response: `{"objects":[],"response":{"error":"Chat is blocked"}}`,
+			err:      chat.ErrSendFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fp := mocks.NewMockFunpay(ctrl)
+			fpChat := chat.New(fp)
+
+			fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+			fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+			fp.EXPECT().Request(
+				t.Context(),
+				"https://funpay.com/runner/",
+				gomock.Any(),
+			).Times(1).Return(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(tt.response)),
+			}, nil)
+
+			err := fpChat.Send(t.Context(), "users-1-2", "hello")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+
+	t.Run("request error handling", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpChat := chat.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+		fp.EXPECT().Request(
+			t.Context(),
+			"https://funpay.com/runner/",
+			gomock.Any(),
+		).Times(1).Return(nil, errors.New("request error"))
+
+		if err := fpChat.Send(t.Context(), "users-1-2", "hello"); err == nil {
+			t.Error("expected error when request fails")
+		}
+	})
+}
+
+func TestChat_Chats(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fp := mocks.NewMockFunpay(ctrl)
+	fpChat := chat.New(fp)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+		<body data-app-data='{"userId":1,"csrf-token":"test","locale":"ru"}'>
+			<a href="https://funpay.com/chat/?node=100" class="contact-item unread" data-id="100" data-node-msg="15">
+				<div class="media-user-name">buyer</div>
+				<div class="contact-item-message">hello</div>
+			</a>
+			<a href="https://funpay.com/chat/?node=101" class="contact-item" data-id="101" data-node-msg="7">
+				<div class="media-user-name">other</div>
+				<div class="contact-item-message">bye</div>
+			</a>
+		</body>
+	</html>`))
+	if err != nil {
+		t.Fatal("invalid doc provided")
+	}
+
+	fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+	fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/chat/").Times(1).Return(doc, nil)
+
+	contacts, err := fpChat.Chats(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []chat.Contact{
+		{ID: "100", Username: "buyer", LastMessage: "hello", LastMessageID: 15, Unread: true},
+		{ID: "101", Username: "other", LastMessage: "bye", LastMessageID: 7},
+	}
+
+	if !reflect.DeepEqual(contacts, expected) {
+		t.Errorf("expected %+v, got %+v", expected, contacts)
+	}
+
+	if contacts[0].ID.Type() != chat.ChatTypePrivate {
+		t.Errorf("expected private chat, got %q", contacts[0].ID.Type())
+	}
+
+	if chat.ChatID("flood-ru").Type() != chat.ChatTypePublic {
+		t.Errorf("expected public chat, got %q", chat.ChatID("flood-ru").Type())
+	}
+}
+
+func TestChat_History(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fp := mocks.NewMockFunpay(ctrl)
+	fpChat := chat.New(fp)
+
+	fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+	fp.EXPECT().Request(
+		t.Context(),
+		"https://funpay.com/chat/history?last_message=0&node=100",
+		gomock.Any(),
+	).Times(1).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`{"chat":{"messages":[
+			{"id":1,"author":25,"html":"<div class=\"chat-msg-item\"><a class=\"chat-msg-author-link\">buyer</a><div class=\"chat-msg-text\">hello</div></div>"},
+			{"id":2,"author":0,"html":"<div class=\"chat-msg-item\"><div class=\"chat-msg-text\">order paid</div></div>"}
+		]}}`)),
+	}, nil)
+
+	messages, err := fpChat.History(t.Context(), "100")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []chat.Message{
+		{ID: 1, AuthorID: 25, Author: "buyer", Text: "hello"},
+		{ID: 2, Text: "order paid"},
+	}
+
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("expected %+v, got %+v", expected, messages)
+	}
+}
@@ -0,0 +1,293 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/chat"
+	"github.com/kostromin59/funpay/goods"
+	"github.com/kostromin59/funpay/lots"
+	"github.com/kostromin59/funpay/orders"
+)
+
+var (
+	// ErrInvalidRule indicates that [Rule] has no name or source.
+	ErrInvalidRule = errors.New("invalid rule")
+
+	// ErrOutOfStock indicates that goods source of matched rule is empty.
+	ErrOutOfStock = errors.New("out of stock")
+
+	// ErrUnknownBuyer indicates that buyer of the order can not be detected.
+	ErrUnknownBuyer = errors.New("unknown buyer")
+)
+
+// Rule describes which orders are delivered from the source.
+// Empty NodeID and nil Description match every order. The first matched rule is used.
+type Rule struct {
+	// Name is saved into [Record] to identify the rule.
+	Name string
+
+	// NodeID matches [orders.Order.NodeID]. Order page is loaded to detect node.
+	NodeID lots.NodeID
+
+	// Description matches [orders.Order.Description] or [orders.Order.Subcategory].
+	Description *regexp.Regexp
+
+	Source goods.GoodsSource
+
+	// Message builds text sent to the buyer. Defaults to the item itself.
+	Message func(order orders.Order, item string) string
+}
+
+func (r Rule) validate() error {
+	if r.Name == "" || r.Source == nil {
+		return fmt.Errorf("%w (%q)", ErrInvalidRule, r.Name)
+	}
+
+	return nil
+}
+
+func (r Rule) match(order orders.Order) bool {
+	if r.NodeID != "" && r.NodeID != order.NodeID {
+		return false
+	}
+
+	if r.Description != nil && !r.Description.MatchString(order.Description) && !r.Description.MatchString(order.Subcategory) {
+		return false
+	}
+
+	return true
+}
+
+func (r Rule) message(order orders.Order, item string) string {
+	if r.Message == nil {
+		return item
+	}
+
+	return r.Message(order, item)
+}
+
+// Bot watches paid orders and sends goods to buyers via chat.
+//
+// Item is consumed from the source before it is sent and returned back (see [goods.GoodsSource.Return])
+// if sending fails. Orders saved into [Store] are never delivered again, the same order is never
+// delivered concurrently. Orders sent while [Store] failed to save them are remembered in memory
+// and are not delivered again until restart.
+type Bot struct {
+	orders orders.Orders
+	chat   chat.Chat
+	store  Store
+	rules  []Rule
+
+	// inFlight contains orders being delivered right now.
+	inFlight map[orders.OrderID]struct{}
+
+	// unsaved contains orders which were sent but not saved into the store.
+	unsaved map[orders.OrderID]struct{}
+	mu      sync.Mutex
+
+	// takeMu makes peek and consume of the item atomic for sources shared between rules.
+	takeMu sync.Mutex
+}
+
+// New creates [Bot]. Returns [ErrInvalidRule] if any rule is invalid.
+func New(o orders.Orders, c chat.Chat, store Store, rules ...Rule) (*Bot, error) {
+	const op = "delivery.New"
+
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return &Bot{
+		orders:   o,
+		chat:     c,
+		store:    store,
+		rules:    rules,
+		inFlight: make(map[orders.OrderID]struct{}),
+		unsaved:  make(map[orders.OrderID]struct{}),
+	}, nil
+}
+
+// Poll loads sales and delivers every paid order. An order which can not be delivered is retried on the next poll
+// (unless it was sent, see [Bot]), the error of each such order is included into the returned one.
+func (b *Bot) Poll(ctx context.Context) error {
+	const op = "Bot.Poll"
+
+	sales, err := b.orders.Sales(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var errs []error
+	for _, order := range sales {
+		if order.Status != orders.StatusPaid {
+			continue
+		}
+
+		if _, err := b.Deliver(ctx, order); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Deliver sends item of the first matched rule to the buyer.
+// Returns false if order is already delivered, is being delivered by another call or there is no matched rule.
+// Returns true and error if the item was sent but the delivery was not saved.
+func (b *Bot) Deliver(ctx context.Context, order orders.Order) (bool, error) {
+	const op = "Bot.Deliver"
+
+	if !b.acquire(order.ID) {
+		return false, nil
+	}
+	defer b.release(order.ID)
+
+	delivered, err := b.store.Delivered(ctx, order.ID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if delivered {
+		return false, nil
+	}
+
+	if order.BuyerID == 0 || (order.NodeID == "" && b.needNode()) {
+		details, err := b.orders.Get(ctx, order.ID)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		order.NodeID = details.NodeID
+		if order.BuyerID == 0 {
+			order.BuyerID, order.BuyerName = details.BuyerID, details.BuyerName
+		}
+	}
+
+	if order.BuyerID == 0 {
+		return false, fmt.Errorf("%s: %w (order %s)", op, ErrUnknownBuyer, order.ID)
+	}
+
+	rule, ok := b.match(order)
+	if !ok {
+		return false, nil
+	}
+
+	item, err := b.take(ctx, rule.Source)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if item == "" {
+		return false, fmt.Errorf("%s: %w (rule %q, order %s)", op, ErrOutOfStock, rule.Name, order.ID)
+	}
+
+	chatID := chat.PrivateChatID(order.SellerID, order.BuyerID)
+	if err := b.chat.Send(ctx, chatID, rule.message(order, item)); err != nil {
+		if returnErr := rule.Source.Return(ctx, []string{item}); returnErr != nil {
+			err = errors.Join(err, returnErr)
+		}
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	record := Record{
+		OrderID: order.ID,
+		Rule:    rule.Name,
+		Item:    item,
+		Time:    time.Now(),
+	}
+
+	if err := b.store.Save(ctx, record); err != nil {
+		b.mu.Lock()
+		b.unsaved[order.ID] = struct{}{}
+		b.mu.Unlock()
+
+		return true, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return true, nil
+}
+
+// Run polls sales every interval until context is done: the interval is the longest time a buyer waits for the item.
+// Errors of [Bot.Poll] are passed to onError if it is not nil. Returns [funpay.ErrInvalidInterval] if interval is not positive.
+func (b *Bot) Run(ctx context.Context, interval time.Duration, onError func(err error)) error {
+	return funpay.RunEvery(ctx, interval, b.Poll, onError)
+}
+
+// acquire marks order as being delivered. Returns false if the order is already being delivered
+// or was sent without saving.
+func (b *Bot) acquire(orderID orders.OrderID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.inFlight[orderID]; ok {
+		return false
+	}
+
+	if _, ok := b.unsaved[orderID]; ok {
+		return false
+	}
+
+	b.inFlight[orderID] = struct{}{}
+
+	return true
+}
+
+func (b *Bot) release(orderID orders.OrderID) {
+	b.mu.Lock()
+	delete(b.inFlight, orderID)
+	b.mu.Unlock()
+}
+
+// take consumes the first item of the source. Returns empty string if source is empty.
+func (b *Bot) take(ctx context.Context, source goods.GoodsSource) (string, error) {
+	b.takeMu.Lock()
+	defer b.takeMu.Unlock()
+
+	items, err := source.Peek(ctx, 1)
+	if err != nil {
+		return "", err
+	}
+
+	if len(items) == 0 {
+		return "", nil
+	}
+
+	if err := source.Consume(ctx, items); err != nil {
+		return "", err
+	}
+
+	return items[0], nil
+}
+
+func (b *Bot) needNode() bool {
+	for _, rule := range b.rules {
+		if rule.NodeID != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *Bot) match(order orders.Order) (Rule, bool) {
+	for _, rule := range b.rules {
+		if rule.match(order) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
@@ -0,0 +1,242 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/kostromin59/funpay/chat"
+	"github.com/kostromin59/funpay/delivery"
+	"github.com/kostromin59/funpay/goods"
+	"github.com/kostromin59/funpay/mocks"
+	"github.com/kostromin59/funpay/orders"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := delivery.New(mocks.NewMockOrders(ctrl), mocks.NewMockChat(ctrl), delivery.NewMemoryStore(), delivery.Rule{
+		Name: "without source",
+	})
+	if !errors.Is(err, delivery.ErrInvalidRule) {
+		t.Fatalf("expected ErrInvalidRule, got %v", err)
+	}
+}
+
+func TestBot_Poll(t *testing.T) {
+	t.Parallel()
+	t.Run("delivers paid orders once", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpOrders := mocks.NewMockOrders(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+		store := delivery.NewMemoryStore()
+		keys := goods.NewMemorySource("key-1", "key-2")
+
+		bot, err := delivery.New(fpOrders, fpChat, store,
+			delivery.Rule{
+				Name:        "accounts",
+				Description: regexp.MustCompile(`(?i)account`),
+				Source:      goods.NewMemorySource("account"),
+			},
+			delivery.Rule{
+				Name:   "keys",
+				NodeID: "1000",
+				Source: keys,
+				Message: func(order orders.Order, item string) string {
+					return order.BuyerName + ", your key: " + item
+				},
+			},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sales := []orders.Order{
+			{ID: "A", Status: orders.StatusPaid, Description: "Steam key", BuyerID: 25, BuyerName: "buyer", SellerID: 1},
+			{ID: "B", Status: orders.StatusClosed, Description: "Steam key", BuyerID: 25, SellerID: 1},
+		}
+
+		fpOrders.EXPECT().Sales(t.Context()).Times(2).Return(sales, nil)
+		fpOrders.EXPECT().Get(t.Context(), orders.OrderID("A")).Times(1).Return(orders.Order{ID: "A", NodeID: "1000"}, nil)
+		fpChat.EXPECT().Send(t.Context(), chat.ChatID("users-1-25"), "buyer, your key: key-1").Times(1).Return(nil)
+
+		if err := bot.Poll(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := bot.Poll(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if n, _ := keys.Len(t.Context()); n != 1 {
+			t.Errorf("expected 1 key left, got %d", n)
+		}
+	})
+
+	t.Run("out of stock", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpOrders := mocks.NewMockOrders(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+
+		bot, err := delivery.New(fpOrders, fpChat, delivery.NewMemoryStore(), delivery.Rule{
+			Name:   "keys",
+			Source: goods.NewMemorySource(),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fpOrders.EXPECT().Sales(t.Context()).Times(1).Return([]orders.Order{
+			{ID: "A", Status: orders.StatusPaid, BuyerID: 25, SellerID: 1},
+		}, nil)
+
+		if err := bot.Poll(t.Context()); !errors.Is(err, delivery.ErrOutOfStock) {
+			t.Fatalf("expected ErrOutOfStock, got %v", err)
+		}
+	})
+
+	t.Run("keeps item when send fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpOrders := mocks.NewMockOrders(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+		store := delivery.NewMemoryStore()
+		keys := goods.NewMemorySource("key-1")
+
+		bot, err := delivery.New(fpOrders, fpChat, store, delivery.Rule{
+			Name:   "keys",
+			Source: keys,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fpChat.EXPECT().Send(t.Context(), gomock.Any(), "key-1").Times(1).Return(chat.ErrSendFailed)
+
+		delivered, err := bot.Deliver(t.Context(), orders.Order{ID: "A", Status: orders.StatusPaid, BuyerID: 25, SellerID: 1})
+		if !errors.Is(err, chat.ErrSendFailed) {
+			t.Fatalf("expected ErrSendFailed, got %v", err)
+		}
+
+		if delivered {
+			t.Error("expected order not to be delivered")
+		}
+
+		if n, _ := keys.Len(t.Context()); n != 1 {
+			t.Errorf("expected key to stay in source, got %d", n)
+		}
+
+		if ok, _ := store.Delivered(t.Context(), "A"); ok {
+			t.Error("expected order not to be recorded")
+		}
+	})
+
+	t.Run("skips order being delivered", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpOrders := mocks.NewMockOrders(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+		keys := goods.NewMemorySource("key-1", "key-2")
+
+		bot, err := delivery.New(fpOrders, fpChat, delivery.NewMemoryStore(), delivery.Rule{
+			Name:   "keys",
+			Source: keys,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		order := orders.Order{ID: "A", Status: orders.StatusPaid, BuyerID: 25, SellerID: 1}
+
+		fpChat.EXPECT().Send(t.Context(), gomock.Any(), "key-1").Times(1).DoAndReturn(func(ctx context.Context, chatID chat.ChatID, text string) error {
+			delivered, err := bot.Deliver(ctx, order)
+			if err != nil || delivered {
+				t.Errorf("expected concurrent delivery to be skipped, got %v, %v", delivered, err)
+			}
+
+			return nil
+		})
+
+		delivered, err := bot.Deliver(t.Context(), order)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !delivered {
+			t.Error("expected order to be delivered")
+		}
+
+		if n, _ := keys.Len(t.Context()); n != 1 {
+			t.Errorf("expected 1 key left, got %d", n)
+		}
+	})
+
+	t.Run("does not resend when save fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpOrders := mocks.NewMockOrders(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+		keys := goods.NewMemorySource("key-1", "key-2")
+
+		bot, err := delivery.New(fpOrders, fpChat, failingStore{}, delivery.Rule{
+			Name:   "keys",
+			Source: keys,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		order := orders.Order{ID: "A", Status: orders.StatusPaid, BuyerID: 25, SellerID: 1}
+
+		fpChat.EXPECT().Send(t.Context(), gomock.Any(), "key-1").Times(1).Return(nil)
+
+		delivered, err := bot.Deliver(t.Context(), order)
+		if !errors.Is(err, errSave) || !delivered {
+			t.Fatalf("expected delivered order with save error, got %v, %v", delivered, err)
+		}
+
+		delivered, err = bot.Deliver(t.Context(), order)
+		if err != nil || delivered {
+			t.Errorf("expected order to be skipped, got %v, %v", delivered, err)
+		}
+
+		if n, _ := keys.Len(t.Context()); n != 1 {
+			t.Errorf("expected 1 key left, got %d", n)
+		}
+	})
+}
+
+var errSave = errors.New("save")
+
+// failingStore never saves records.
+type failingStore struct{}
+
+func (failingStore) Delivered(ctx context.Context, orderID orders.OrderID) (bool, error) {
+	return false, nil
+}
+
+func (failingStore) Save(ctx context.Context, record delivery.Record) error {
+	return errSave
+}
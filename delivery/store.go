@@ -0,0 +1,147 @@
+package delivery
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kostromin59/funpay/orders"
+)
+
+// Record represents delivered order.
+type Record struct {
+	OrderID orders.OrderID `json:"order_id"`
+	Rule    string         `json:"rule"`
+	Item    string         `json:"item"`
+	Time    time.Time      `json:"time"`
+}
+
+// Store keeps delivered orders to prevent duplicate deliveries.
+type Store interface {
+	// Delivered reports whether order was already delivered.
+	Delivered(ctx context.Context, orderID orders.OrderID) (bool, error)
+
+	// Save records delivery of the order.
+	Save(ctx context.Context, record Record) error
+}
+
+// MemoryStore is in-memory implementation of [Store]. Records are lost on restart.
+type MemoryStore struct {
+	records map[orders.OrderID]Record
+	mu      sync.RWMutex
+}
+
+// NewMemoryStore creates empty [MemoryStore].
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[orders.OrderID]Record),
+	}
+}
+
+func (s *MemoryStore) Delivered(ctx context.Context, orderID orders.OrderID) (bool, error) {
+	s.mu.RLock()
+	_, ok := s.records[orderID]
+	s.mu.RUnlock()
+
+	return ok, nil
+}
+
+func (s *MemoryStore) Save(ctx context.Context, record Record) error {
+	s.mu.Lock()
+	s.records[record.OrderID] = record
+	s.mu.Unlock()
+
+	return nil
+}
+
+// FileStore is [Store] backed by append-only file with one JSON record per line.
+// Records are loaded into memory on creation, so it survives restarts.
+// Saved record is kept in memory even if writing into the file fails.
+type FileStore struct {
+	path    string
+	records map[orders.OrderID]Record
+	mu      sync.RWMutex
+}
+
+// NewFileStore creates [FileStore] and loads existing records. File is created on the first save.
+func NewFileStore(path string) (*FileStore, error) {
+	const op = "NewFileStore"
+
+	s := &FileStore{
+		path:    path,
+		records: make(map[orders.OrderID]Record),
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		s.records[record.OrderID] = record
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) Delivered(ctx context.Context, orderID orders.OrderID) (bool, error) {
+	s.mu.RLock()
+	_, ok := s.records[orderID]
+	s.mu.RUnlock()
+
+	return ok, nil
+}
+
+func (s *FileStore) Save(ctx context.Context, record Record) error {
+	const op = "FileStore.Save"
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.OrderID] = record
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
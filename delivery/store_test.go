@@ -0,0 +1,61 @@
+package delivery_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kostromin59/funpay/delivery"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "deliveries.jsonl")
+
+	store, err := delivery.NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	record := delivery.Record{OrderID: "ABCD", Rule: "keys", Item: "key", Time: time.Now()}
+	if err := store.Save(t.Context(), record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	restored, err := delivery.NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	delivered, err := restored.Delivered(t.Context(), "ABCD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !delivered {
+		t.Error("expected order to be delivered after restart")
+	}
+
+	delivered, err = restored.Delivered(t.Context(), "QWER")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if delivered {
+		t.Error("expected unknown order not to be delivered")
+	}
+
+	broken, err := delivery.NewFileStore(filepath.Join(t.TempDir(), "missing", "deliveries.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := broken.Save(t.Context(), record); err == nil {
+		t.Fatal("expected error for missing directory")
+	}
+
+	if delivered, _ := broken.Delivered(t.Context(), "ABCD"); !delivered {
+		t.Error("expected order to be delivered after failed write")
+	}
+}
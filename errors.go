@@ -0,0 +1,123 @@
+package funpay
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPErrorBodyLimit is the maximum size of response body snippet stored in [HTTPError].
+const HTTPErrorBodyLimit = 512
+
+// HTTPError is returned by [FunpayRequester.Request] if response status code is not 2xx.
+// It wraps one of sentinels, so errors.Is works as before:
+//   - [ErrAccountUnauthorized] if status code equals 403,
+//   - [ErrTooManyRequests] if status code equals 429,
+//   - [ErrBadStatusCode] otherwise.
+//
+// Use errors.As to get response details:
+//
+//	var httpErr *funpay.HTTPError
+//	if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
+//		time.Sleep(httpErr.RetryAfter)
+//	}
+type HTTPError struct {
+	// StatusCode is the response status code.
+	StatusCode int
+
+	// Method is the request method.
+	Method string
+
+	// URL is the request URL with redacted golden key and CSRF token (see [Redacted]).
+	URL string
+
+	// RetryAfter is parsed Retry-After header (seconds or HTTP date). Zero if header is missing or invalid.
+	RetryAfter time.Duration
+
+	// Body is the beginning of response body, truncated to [HTTPErrorBodyLimit] bytes.
+	Body string
+
+	// Err is the sentinel error matched by the status code.
+	Err error
+}
+
+// newHTTPError creates [HTTPError] from the response. Body snippet is read without consuming the body:
+// the response body still can be read from the beginning.
+func newHTTPError(req *http.Request, resp *http.Response) *HTTPError {
+	err := ErrBadStatusCode
+	switch resp.StatusCode {
+	case http.StatusForbidden:
+		err = ErrAccountUnauthorized
+	case http.StatusTooManyRequests:
+		err = ErrTooManyRequests
+	}
+
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        urlAttr("url", req.URL).Value.String(),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Body:       peekBody(resp, HTTPErrorBodyLimit),
+		Err:        err,
+	}
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err == ErrBadStatusCode {
+		return fmt.Sprintf("%s (%d): %s %s", e.Err, e.StatusCode, e.Method, e.URL)
+	}
+
+	return fmt.Sprintf("%s: %s %s", e.Err, e.Method, e.URL)
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// parseRetryAfter parses Retry-After header value as delay in seconds or HTTP date relative to now.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0
+	}
+
+	return max(date.Sub(now), 0)
+}
+
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// peekBody reads up to limit bytes of the response body and puts them back.
+func peekBody(resp *http.Response, limit int64) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, limit))
+	resp.Body = peekedBody{
+		Reader: io.MultiReader(bytes.NewReader(snippet), resp.Body),
+		Closer: resp.Body,
+	}
+
+	// Snippet may end in the middle of multibyte rune.
+	return strings.ToValidUTF8(string(snippet), "")
+}
@@ -0,0 +1,113 @@
+package funpay_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kostromin59/funpay"
+)
+
+func TestHTTPError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status     int
+		retryAfter string
+		sentinel   error
+		expected   time.Duration
+	}{
+		"unauthorized": {
+			status:   http.StatusForbidden,
+			sentinel: funpay.ErrAccountUnauthorized,
+		},
+		"too many requests with seconds": {
+			status:     http.StatusTooManyRequests,
+			retryAfter: "30",
+			sentinel:   funpay.ErrTooManyRequests,
+			expected:   30 * time.Second,
+		},
+		"too many requests with invalid value": {
+			status:     http.StatusTooManyRequests,
+			retryAfter: "soon",
+			sentinel:   funpay.ErrTooManyRequests,
+		},
+		"bad status code": {
+			status:   http.StatusBadGateway,
+			sentinel: funpay.ErrBadStatusCode,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			body := strings.Repeat("a", funpay.HTTPErrorBodyLimit) + "tail"
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(body))
+			}))
+			defer ts.Close()
+
+			fp := funpay.New("test_key", "test_agent")
+
+			resp, err := fp.Request(t.Context(), ts.URL+"/lots/?csrf_token=csrf_secret", funpay.RequestWithMethod(http.MethodPost))
+			if !errors.Is(err, tt.sentinel) {
+				t.Fatalf("expected %v, got %v", tt.sentinel, err)
+			}
+			defer resp.Body.Close()
+
+			var httpErr *funpay.HTTPError
+			if !errors.As(err, &httpErr) {
+				t.Fatalf("expected *HTTPError, got %T", err)
+			}
+
+			if httpErr.StatusCode != tt.status || httpErr.Method != http.MethodPost || httpErr.RetryAfter != tt.expected {
+				t.Errorf("unexpected error details: %+v", httpErr)
+			}
+
+			if !strings.Contains(httpErr.URL, "/lots/") || strings.Contains(httpErr.URL, "csrf_secret") {
+				t.Errorf("URL contains CSRF token: %s", httpErr.URL)
+			}
+
+			if httpErr.Body != body[:funpay.HTTPErrorBodyLimit] {
+				t.Errorf("expected body snippet of %d bytes, got %d", funpay.HTTPErrorBodyLimit, len(httpErr.Body))
+			}
+
+			full, _ := io.ReadAll(resp.Body)
+			if string(full) != body {
+				t.Errorf("expected full body after snippet, got %d bytes", len(full))
+			}
+		})
+	}
+}
+
+func TestHTTPError_RetryAfterDate(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	fp := funpay.New("test_key", "test_agent")
+
+	_, err := fp.Request(t.Context(), ts.URL)
+
+	var httpErr *funpay.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("expected *HTTPError, got %v", err)
+	}
+
+	if httpErr.RetryAfter <= 50*time.Second || httpErr.RetryAfter > time.Minute {
+		t.Errorf("unexpected retry after: %v", httpErr.RetryAfter)
+	}
+}
@@ -0,0 +1,258 @@
+package finance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/orders"
+)
+
+// TransactionID represents ID of transaction.
+type TransactionID string
+
+// TransactionStatus represents status of transaction.
+type TransactionStatus string
+
+const (
+	TransactionStatusWaiting  TransactionStatus = "waiting"
+	TransactionStatusComplete TransactionStatus = "complete"
+	TransactionStatusCancel   TransactionStatus = "cancel"
+)
+
+// Transaction represents row of transactions history on /account/balance page.
+type Transaction struct {
+	ID          TransactionID
+	Date        string
+	Type        string
+	Description string
+	Amount      funpay.Money
+	Status      TransactionStatus
+
+	// OrderID is filled if transaction is related to the order.
+	OrderID orders.OrderID
+}
+
+// TransactionsPage represents a page of transactions history.
+type TransactionsPage struct {
+	Transactions []Transaction
+
+	// Next is a cursor for the next page. Empty if there are no more transactions.
+	Next string
+}
+
+//go:generate go tool mockgen -destination ../mocks/finance.go -package mocks . Finance
+type Finance interface {
+	// Balances loads balance per currency from /account/balance.
+	Balances(ctx context.Context) ([]funpay.Money, error)
+
+	// Transactions loads page of transactions history.
+	// Provide empty cursor to load the first page or [TransactionsPage.Next] to load the next one.
+	// Returns [funpay.ErrAccountUnauthorized] if user id equals 0.
+	Transactions(ctx context.Context, cursor string) (TransactionsPage, error)
+
+	// PreviewWithdrawal calculates commission and amount received for withdrawal without creating it.
+	// Returns [ErrUnsupportedMethod] if method does not support currency.
+	PreviewWithdrawal(ctx context.Context, currency funpay.Currency, method WithdrawalMethod, amount int64) (WithdrawalPreview, error)
+
+	// Withdraw creates withdrawal of amount (in minor units) from balance in currency to the wallet.
+	// Returns [ErrUnsupportedMethod] if method does not support currency and [ErrInsufficientFunds]
+	// if balance is less than amount.
+	Withdraw(ctx context.Context, currency funpay.Currency, method WithdrawalMethod, wallet string, amount int64) error
+
+	// PendingWithdrawals loads withdrawals which are not completed or canceled yet.
+	// Returns [funpay.ErrAccountUnauthorized] if user id equals 0.
+	PendingWithdrawals(ctx context.Context) ([]Transaction, error)
+}
+
+type FinanceClient struct {
+	fp funpay.Funpay
+}
+
+func New(fp funpay.Funpay) Finance {
+	return &FinanceClient{
+		fp: fp,
+	}
+}
+
+// logger returns logger of the client (see [funpay.FunpayRequester.Logger]).
+func (f *FinanceClient) logger() *slog.Logger {
+	return f.fp.Logger().With(slog.String("module", "finance"))
+}
+
+func (f *FinanceClient) Balances(ctx context.Context) ([]funpay.Money, error) {
+	const op = "FinanceClient.Balances"
+
+	doc, err := f.balancePage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	balances, err := extractBalances(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return balances, nil
+}
+
+func (f *FinanceClient) Transactions(ctx context.Context, cursor string) (TransactionsPage, error) {
+	const op = "FinanceClient.Transactions"
+
+	userID := f.fp.UserID()
+	if userID == 0 {
+		return TransactionsPage{}, fmt.Errorf("%s: %w", op, funpay.ErrAccountUnauthorized)
+	}
+
+	var doc *goquery.Document
+	if cursor == "" {
+		page, err := f.balancePage(ctx)
+		if err != nil {
+			return TransactionsPage{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		doc = page
+	} else {
+		page, err := f.nextTransactions(ctx, userID, cursor, "")
+		if err != nil {
+			return TransactionsPage{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		doc = page
+	}
+
+	page, err := extractTransactions(doc)
+	if err != nil {
+		return TransactionsPage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
+}
+
+func (f *FinanceClient) balancePage(ctx context.Context) (*goquery.Document, error) {
+	const op = "FinanceClient.balancePage"
+
+	reqURL, err := funpay.URL("account", "balance").WithBaseURL(f.fp.BaseURL()).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := f.fp.RequestHTML(ctx, reqURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return doc, nil
+}
+
+// nextTransactions loads the next page of transactions filtered by type (empty filter for all types).
+// Response is HTML fragment without app data, so [funpay.FunpayRequester.Request] is used
+// instead of [funpay.FunpayRequester.RequestHTML].
+func (f *FinanceClient) nextTransactions(ctx context.Context, userID int64, cursor, filter string) (*goquery.Document, error) {
+	const op = "FinanceClient.nextTransactions"
+
+	body := url.Values{}
+	body.Set("user_id", strconv.FormatInt(userID, 10))
+	body.Set("continue", cursor)
+	body.Set("filter", filter)
+	body.Set(funpay.FormCSRFToken, f.fp.CSRFToken())
+
+	resp, err := f.fp.Request(ctx, funpay.URL("users", "transactions").WithBaseURL(f.fp.BaseURL()).String(),
+		funpay.RequestWithMethod(http.MethodPost),
+		funpay.RequestWithBody(bytes.NewBufferString(body.Encode())),
+		funpay.RequestWithHeaders(funpay.RequestPostHeaders),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return doc, nil
+}
+
+func extractBalances(doc *goquery.Document) ([]funpay.Money, error) {
+	const op = "finance.extractBalances"
+
+	values := doc.Find(".balances-value")
+	balances := make([]funpay.Money, 0, values.Length())
+	for _, value := range values.EachIter() {
+		amount, err := funpay.ParseMoney(value.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		balances = append(balances, amount)
+	}
+
+	return balances, nil
+}
+
+func extractTransactions(doc *goquery.Document) (TransactionsPage, error) {
+	const op = "finance.extractTransactions"
+
+	items := doc.Find(".tc-item[data-transaction]")
+	page := TransactionsPage{
+		Transactions: make([]Transaction, 0, items.Length()),
+		Next:         doc.Find(".dyn-table-form input[name=continue]").AttrOr("value", ""),
+	}
+
+	for _, item := range items.EachIter() {
+		amount, err := funpay.ParseMoney(item.Find(".tc-price").Text())
+		if err != nil {
+			return TransactionsPage{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		transaction := Transaction{
+			ID:          TransactionID(item.AttrOr("data-transaction", "")),
+			Date:        strings.TrimSpace(item.Find(".tc-date-time").Text()),
+			Type:        strings.TrimSpace(item.Find(".tc-title").Text()),
+			Description: strings.TrimSpace(item.Find(".tc-desc").Text()),
+			Amount:      amount,
+			Status:      parseTransactionStatus(item),
+		}
+
+		for _, link := range item.Find("a[href]").EachIter() {
+			if orderID := parseOrderID(link.AttrOr("href", "")); orderID != "" {
+				transaction.OrderID = orderID
+				break
+			}
+		}
+
+		page.Transactions = append(page.Transactions, transaction)
+	}
+
+	return page, nil
+}
+
+// parseTransactionStatus detects status by transaction-status-* class.
+func parseTransactionStatus(s *goquery.Selection) TransactionStatus {
+	for _, status := range []TransactionStatus{TransactionStatusWaiting, TransactionStatusComplete, TransactionStatusCancel} {
+		if s.HasClass("transaction-status-" + string(status)) {
+			return status
+		}
+	}
+
+	return ""
+}
+
+// parseOrderID parses links like /orders/{id}/.
+func parseOrderID(href string) orders.OrderID {
+	id, ok := funpay.LinkID(href, "orders")
+	if !ok || id == "trade" {
+		return ""
+	}
+
+	return orders.OrderID(id)
+}
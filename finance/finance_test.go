@@ -0,0 +1,211 @@
+package finance_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/finance"
+	"github.com/kostromin59/funpay/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+const balancePage = `<html>
+	<body data-app-data='{"userId":1,"csrf-token":"csrf","locale":"ru"}'>
+		<div class="balances">
+			<span class="balances-value">1 234,56 ₽</span>
+			<span class="balances-value">10.5 $</span>
+			<span class="balances-value">0 €</span>
+		</div>
+		<div class="tc finance-table">
+			<div class="tc-item transaction-status-complete" data-transaction="101">
+				<div class="tc-date-time">10 апреля, 12:00</div>
+				<div class="tc-title">Заказ #ABCD1234</div>
+				<div class="tc-desc">Steam key, <a href="https://funpay.com/orders/ABCD1234/">#ABCD1234</a></div>
+				<div class="tc-price">+ 100,00 ₽</div>
+			</div>
+			<div class="tc-item transaction-status-waiting" data-transaction="102">
+				<div class="tc-date-time">11 апреля, 13:00</div>
+				<div class="tc-title">Вывод денег</div>
+				<div class="tc-desc">Qiwi</div>
+				<div class="tc-price">− 50 ₽</div>
+			</div>
+		</div>
+		<form class="dyn-table-form"><input type="hidden" name="continue" value="102"></form>
+	</body>
+</html>`
+
+func TestFinance_Balances(t *testing.T) {
+	t.Parallel()
+	t.Run("successful balances retrieval", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(balancePage))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/balance").Times(1).Return(doc, nil)
+
+		balances, err := fpFinance.Balances(t.Context())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []funpay.Money{
+			{Amount: 123456, Currency: funpay.CurrencyRUB},
+			{Amount: 1050, Currency: funpay.CurrencyUSD},
+			{Amount: 0, Currency: funpay.CurrencyEUR},
+		}
+
+		if !reflect.DeepEqual(balances, expected) {
+			t.Fatalf("expected %v, got %v", expected, balances)
+		}
+	})
+
+	t.Run("request error handling", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/balance").Times(1).Return(nil, funpay.ErrAccountUnauthorized)
+
+		if _, err := fpFinance.Balances(t.Context()); !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Fatalf("expected ErrAccountUnauthorized, got %v", err)
+		}
+	})
+}
+
+func TestFinance_Transactions(t *testing.T) {
+	t.Parallel()
+	t.Run("first page", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(balancePage))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().UserID().Times(1).Return(int64(1))
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/balance").Times(1).Return(doc, nil)
+
+		page, err := fpFinance.Transactions(t.Context(), "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := finance.TransactionsPage{
+			Transactions: []finance.Transaction{
+				{
+					ID:          "101",
+					Date:        "10 апреля, 12:00",
+					Type:        "Заказ #ABCD1234",
+					Description: "Steam key, #ABCD1234",
+					Amount:      funpay.Money{Amount: 10000, Currency: funpay.CurrencyRUB},
+					Status:      finance.TransactionStatusComplete,
+					OrderID:     "ABCD1234",
+				},
+				{
+					ID:          "102",
+					Date:        "11 апреля, 13:00",
+					Type:        "Вывод денег",
+					Description: "Qiwi",
+					Amount:      funpay.Money{Amount: -5000, Currency: funpay.CurrencyRUB},
+					Status:      finance.TransactionStatusWaiting,
+				},
+			},
+			Next: "102",
+		}
+
+		if !reflect.DeepEqual(page, expected) {
+			t.Fatalf("expected %+v, got %+v", expected, page)
+		}
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		fp.EXPECT().UserID().Times(1).Return(int64(1))
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+		fp.EXPECT().Request(
+			t.Context(),
+			"https://funpay.com/users/transactions",
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+		).Times(1).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`<div class="tc-item transaction-status-cancel" data-transaction="90">
+				<div class="tc-title">Вывод денег</div>
+				<div class="tc-price">-10 $</div>
+			</div>`)),
+		}, nil)
+
+		page, err := fpFinance.Transactions(t.Context(), "102")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := finance.TransactionsPage{
+			Transactions: []finance.Transaction{
+				{
+					ID:     "90",
+					Type:   "Вывод денег",
+					Amount: funpay.Money{Amount: -1000, Currency: funpay.CurrencyUSD},
+					Status: finance.TransactionStatusCancel,
+				},
+			},
+		}
+
+		if !reflect.DeepEqual(page, expected) {
+			t.Fatalf("expected %+v, got %+v", expected, page)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		fp.EXPECT().UserID().Times(1).Return(int64(0))
+
+		if _, err := fpFinance.Transactions(t.Context(), ""); !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Fatalf("expected ErrAccountUnauthorized, got %v", err)
+		}
+	})
+}
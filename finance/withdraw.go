@@ -0,0 +1,298 @@
+package finance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kostromin59/funpay"
+)
+
+var (
+	// ErrUnsupportedMethod indicates that withdrawal method is unknown or does not support currency.
+	ErrUnsupportedMethod = errors.New("unsupported withdrawal method")
+
+	// ErrInsufficientFunds indicates that balance is less than withdrawal amount.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrWithdrawalFailed indicates that Funpay rejected withdrawal. Error contains message from Funpay.
+	ErrWithdrawalFailed = errors.New("withdrawal failed")
+
+	// ErrInvalidResponse indicates that Funpay response misses required field or contains invalid value.
+	ErrInvalidResponse = errors.New("invalid withdrawal response")
+)
+
+// WithdrawalMethod represents Funpay withdrawal method (ext_currency_id).
+type WithdrawalMethod string
+
+const (
+	WithdrawalMethodCardRUB  WithdrawalMethod = "card_rub"
+	WithdrawalMethodCardUSD  WithdrawalMethod = "card_usd"
+	WithdrawalMethodCardEUR  WithdrawalMethod = "card_eur"
+	WithdrawalMethodSBP      WithdrawalMethod = "fps"
+	WithdrawalMethodYooMoney WithdrawalMethod = "yoomoney"
+	WithdrawalMethodUSDT     WithdrawalMethod = "usdt_trc"
+)
+
+// withdrawalMethods maps methods to currencies of balance they can be used with.
+var withdrawalMethods = map[WithdrawalMethod][]funpay.Currency{
+	WithdrawalMethodCardRUB:  {funpay.CurrencyRUB},
+	WithdrawalMethodCardUSD:  {funpay.CurrencyUSD},
+	WithdrawalMethodCardEUR:  {funpay.CurrencyEUR},
+	WithdrawalMethodSBP:      {funpay.CurrencyRUB},
+	WithdrawalMethodYooMoney: {funpay.CurrencyRUB},
+	WithdrawalMethodUSDT:     {funpay.CurrencyRUB, funpay.CurrencyUSD, funpay.CurrencyEUR},
+}
+
+// WithdrawalPreview represents calculated withdrawal.
+type WithdrawalPreview struct {
+	Amount     funpay.Money
+	Commission funpay.Money
+	Received   funpay.Money
+}
+
+type withdrawalResponse struct {
+	Error any    `json:"error"`
+	Msg   string `json:"msg"`
+
+	// Preview fields. Funpay sends them either as numbers or strings.
+	Commission jsonAmount `json:"commission"`
+	AmountExt  jsonAmount `json:"amount_ext"`
+}
+
+// jsonAmount represents amount sent either as JSON number or string. Numbers are kept as decimal text
+// without float conversion, so minor units are not lost.
+type jsonAmount struct {
+	value string
+	set   bool
+}
+
+func (a *jsonAmount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		a.value, a.set = s, true
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+
+	value := n.String()
+	if strings.ContainsAny(value, "eE") {
+		f, err := n.Float64()
+		if err != nil {
+			return err
+		}
+
+		value = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	a.value, a.set = value, true
+
+	return nil
+}
+
+// money parses the amount. Currency defaults to provided one if the value has no currency sign.
+func (a jsonAmount) money(field string, currency funpay.Currency) (funpay.Money, error) {
+	if !a.set {
+		return funpay.Money{}, fmt.Errorf("%w: missing %s", ErrInvalidResponse, field)
+	}
+
+	m, err := funpay.ParseMoney(a.value)
+	if err != nil {
+		return funpay.Money{}, fmt.Errorf("%w: %s: %w", ErrInvalidResponse, field, err)
+	}
+
+	if m.Currency == "" {
+		m.Currency = currency
+	}
+
+	return m, nil
+}
+
+// err returns message of failed response or empty string. Error may be bool, number or string.
+// Failed response without message returns "unknown error".
+func (r withdrawalResponse) err() string {
+	var failed bool
+	switch e := r.Error.(type) {
+	case bool:
+		failed = e
+	case float64:
+		failed = e != 0
+	case string:
+		return e
+	}
+
+	if !failed {
+		return ""
+	}
+
+	if r.Msg == "" {
+		return "unknown error"
+	}
+
+	return r.Msg
+}
+
+func (f *FinanceClient) PreviewWithdrawal(ctx context.Context, currency funpay.Currency, method WithdrawalMethod, amount int64) (WithdrawalPreview, error) {
+	const op = "FinanceClient.PreviewWithdrawal"
+
+	if err := checkWithdrawalMethod(currency, method); err != nil {
+		return WithdrawalPreview{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := f.withdrawalRequest(ctx, "/withdraw/calc", currency, method, "", amount)
+	if err != nil {
+		return WithdrawalPreview{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	commission, err := resp.Commission.money("commission", currency)
+	if err != nil {
+		return WithdrawalPreview{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	received, err := resp.AmountExt.money("amount_ext", currency)
+	if err != nil {
+		return WithdrawalPreview{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	preview := WithdrawalPreview{
+		Amount:     funpay.Money{Amount: amount, Currency: currency},
+		Commission: commission,
+		Received:   received,
+	}
+
+	return preview, nil
+}
+
+func (f *FinanceClient) Withdraw(ctx context.Context, currency funpay.Currency, method WithdrawalMethod, wallet string, amount int64) error {
+	const op = "FinanceClient.Withdraw"
+
+	if err := checkWithdrawalMethod(currency, method); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	balances, err := f.Balances(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var balance int64
+	for _, b := range balances {
+		if b.Currency == currency {
+			balance = b.Amount
+		}
+	}
+
+	if amount <= 0 || balance < amount {
+		return fmt.Errorf("%s: %w (%s)", op, ErrInsufficientFunds, funpay.Money{Amount: balance, Currency: currency})
+	}
+
+	if _, err := f.withdrawalRequest(ctx, "/withdraw/withdraw", currency, method, wallet, amount); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	f.logger().LogAttrs(ctx, slog.LevelInfo, "withdrawal created",
+		slog.String("method", string(method)),
+		slog.String("amount", funpay.Money{Amount: amount, Currency: currency}.String()),
+	)
+
+	return nil
+}
+
+func (f *FinanceClient) PendingWithdrawals(ctx context.Context) ([]Transaction, error) {
+	const op = "FinanceClient.PendingWithdrawals"
+
+	userID := f.fp.UserID()
+	if userID == 0 {
+		return nil, fmt.Errorf("%s: %w", op, funpay.ErrAccountUnauthorized)
+	}
+
+	var pending []Transaction
+	var cursor string
+	for {
+		doc, err := f.nextTransactions(ctx, userID, cursor, "withdraw")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		page, err := extractTransactions(doc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, transaction := range page.Transactions {
+			if transaction.Status == TransactionStatusWaiting {
+				pending = append(pending, transaction)
+			}
+		}
+
+		if page.Next == "" || page.Next == cursor {
+			return pending, nil
+		}
+
+		cursor = page.Next
+	}
+}
+
+// withdrawalRequest posts withdrawal form to path and decodes response.
+// Returns [ErrWithdrawalFailed] or [ErrInsufficientFunds] if Funpay rejected it.
+func (f *FinanceClient) withdrawalRequest(ctx context.Context, path string, currency funpay.Currency, method WithdrawalMethod, wallet string, amount int64) (withdrawalResponse, error) {
+	const op = "FinanceClient.withdrawalRequest"
+
+	body := url.Values{}
+	body.Set("currency_id", strings.ToLower(string(currency)))
+	body.Set("ext_currency_id", string(method))
+	body.Set("wallet", wallet)
+	body.Set("amount_int", funpay.Money{Amount: amount}.Decimal())
+	body.Set(funpay.FormCSRFToken, f.fp.CSRFToken())
+
+	resp, err := f.fp.Request(ctx, funpay.URL(path).WithBaseURL(f.fp.BaseURL()).String(),
+		funpay.RequestWithMethod(http.MethodPost),
+		funpay.RequestWithBody(bytes.NewBufferString(body.Encode())),
+		funpay.RequestWithHeaders(funpay.RequestPostHeaders),
+	)
+	if err != nil {
+		return withdrawalResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	var withdrawalResp withdrawalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&withdrawalResp); err != nil {
+		return withdrawalResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if msg := withdrawalResp.err(); msg != "" {
+		lower := strings.ToLower(msg)
+		if strings.Contains(lower, "недостаточно") || strings.Contains(lower, "insufficient") {
+			return withdrawalResponse{}, fmt.Errorf("%s: %w (%s)", op, ErrInsufficientFunds, msg)
+		}
+
+		return withdrawalResponse{}, fmt.Errorf("%s: %w (%s)", op, ErrWithdrawalFailed, msg)
+	}
+
+	return withdrawalResp, nil
+}
+
+func checkWithdrawalMethod(currency funpay.Currency, method WithdrawalMethod) error {
+	currencies, ok := withdrawalMethods[method]
+	if !ok || !slices.Contains(currencies, currency) {
+		return fmt.Errorf("%w (%s, %s)", ErrUnsupportedMethod, method, currency)
+	}
+
+	return nil
+}
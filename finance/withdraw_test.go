@@ -0,0 +1,260 @@
+package finance_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/finance"
+	"github.com/kostromin59/funpay/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestFinance_PreviewWithdrawal(t *testing.T) {
+	t.Parallel()
+	t.Run("successful preview", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+		fp.EXPECT().Request(t.Context(), "https://funpay.com/withdraw/calc", gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).Return(jsonResponse(`{"error":0,"commission":"30.5","amount_ext":969.5}`), nil)
+
+		preview, err := fpFinance.PreviewWithdrawal(t.Context(), funpay.CurrencyRUB, finance.WithdrawalMethodCardRUB, 100000)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := finance.WithdrawalPreview{
+			Amount:     funpay.Money{Amount: 100000, Currency: funpay.CurrencyRUB},
+			Commission: funpay.Money{Amount: 3050, Currency: funpay.CurrencyRUB},
+			Received:   funpay.Money{Amount: 96950, Currency: funpay.CurrencyRUB},
+		}
+
+		if !reflect.DeepEqual(preview, expected) {
+			t.Fatalf("expected %v, got %v", expected, preview)
+		}
+	})
+
+	t.Run("large numeric amounts", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+		fp.EXPECT().Request(t.Context(), "https://funpay.com/withdraw/calc", gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).Return(jsonResponse(`{"error":0,"commission":1e+06,"amount_ext":123456789.01}`), nil)
+
+		preview, err := fpFinance.PreviewWithdrawal(t.Context(), funpay.CurrencyRUB, finance.WithdrawalMethodCardRUB, 12445678901)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if preview.Commission.Amount != 100000000 || preview.Received.Amount != 12345678901 {
+			t.Fatalf("unexpected preview: %+v", preview)
+		}
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+		fp.EXPECT().Request(t.Context(), "https://funpay.com/withdraw/calc", gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).Return(jsonResponse(`{"error":0,"commission":"30.5"}`), nil)
+
+		_, err := fpFinance.PreviewWithdrawal(t.Context(), funpay.CurrencyRUB, finance.WithdrawalMethodCardRUB, 100000)
+		if !errors.Is(err, finance.ErrInvalidResponse) || !strings.Contains(err.Error(), "amount_ext") {
+			t.Fatalf("expected ErrInvalidResponse for amount_ext, got %v", err)
+		}
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		_, err := fpFinance.PreviewWithdrawal(t.Context(), funpay.CurrencyUSD, finance.WithdrawalMethodSBP, 100)
+		if !errors.Is(err, finance.ErrUnsupportedMethod) {
+			t.Fatalf("expected ErrUnsupportedMethod, got %v", err)
+		}
+
+		_, err = fpFinance.PreviewWithdrawal(t.Context(), funpay.CurrencyRUB, "paypal", 100)
+		if !errors.Is(err, finance.ErrUnsupportedMethod) {
+			t.Fatalf("expected ErrUnsupportedMethod, got %v", err)
+		}
+	})
+}
+
+func TestFinance_Withdraw(t *testing.T) {
+	t.Parallel()
+	t.Run("successful withdrawal", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(balancePage))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/balance").Times(1).Return(doc, nil)
+		fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+		fp.EXPECT().Request(t.Context(), "https://funpay.com/withdraw/withdraw", gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).Return(jsonResponse(`{"error":0,"url":"https://funpay.com/account/balance"}`), nil)
+		fp.EXPECT().Logger().Times(1).Return(slog.New(slog.DiscardHandler))
+
+		if err := fpFinance.Withdraw(t.Context(), funpay.CurrencyRUB, finance.WithdrawalMethodCardRUB, "2200000000000000", 100000); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(balancePage))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/balance").Times(1).Return(doc, nil)
+
+		err = fpFinance.Withdraw(t.Context(), funpay.CurrencyUSD, finance.WithdrawalMethodUSDT, "wallet", 5000)
+		if !errors.Is(err, finance.ErrInsufficientFunds) {
+			t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+		}
+	})
+
+	t.Run("rejected withdrawal", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpFinance := finance.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(balancePage))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/balance").Times(1).Return(doc, nil)
+		fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+		fp.EXPECT().Request(t.Context(), "https://funpay.com/withdraw/withdraw", gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).Return(jsonResponse(`{"error":1,"msg":"Неверный номер кошелька"}`), nil)
+
+		err = fpFinance.Withdraw(t.Context(), funpay.CurrencyRUB, finance.WithdrawalMethodCardRUB, "1", 1000)
+		if !errors.Is(err, finance.ErrWithdrawalFailed) {
+			t.Fatalf("expected ErrWithdrawalFailed, got %v", err)
+		}
+	})
+
+	for _, body := range []string{`{"error":true}`, `{"error":1,"msg":""}`} {
+		t.Run("rejected without message "+body, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fp := mocks.NewMockFunpay(ctrl)
+			fpFinance := finance.New(fp)
+
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(balancePage))
+			if err != nil {
+				t.Fatal("invalid doc provided")
+			}
+
+			fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+			fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/balance").Times(1).Return(doc, nil)
+			fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+			fp.EXPECT().Request(t.Context(), "https://funpay.com/withdraw/withdraw", gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).Return(jsonResponse(body), nil)
+
+			err = fpFinance.Withdraw(t.Context(), funpay.CurrencyRUB, finance.WithdrawalMethodCardRUB, "1", 1000)
+			if !errors.Is(err, finance.ErrWithdrawalFailed) || !strings.Contains(err.Error(), "unknown error") {
+				t.Fatalf("expected ErrWithdrawalFailed with unknown error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFinance_PendingWithdrawals(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fp := mocks.NewMockFunpay(ctrl)
+	fpFinance := finance.New(fp)
+
+	fp.EXPECT().UserID().Times(1).Return(int64(1))
+	fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+	fp.EXPECT().CSRFToken().Times(2).Return("csrf")
+	gomock.InOrder(
+		fp.EXPECT().Request(t.Context(), "https://funpay.com/users/transactions", gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).Return(jsonResponse(`
+				<div class="tc-item transaction-status-waiting" data-transaction="12"><div class="tc-price">− 50 ₽</div></div>
+				<div class="tc-item transaction-status-complete" data-transaction="11"><div class="tc-price">− 10 ₽</div></div>
+				<form class="dyn-table-form"><input name="continue" value="11"></form>`), nil),
+		fp.EXPECT().Request(t.Context(), "https://funpay.com/users/transactions", gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).Return(jsonResponse(`
+				<div class="tc-item transaction-status-waiting" data-transaction="5"><div class="tc-price">− 1 $</div></div>`), nil),
+	)
+
+	pending, err := fpFinance.PendingWithdrawals(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pending) != 2 || pending[0].ID != "12" || pending[1].ID != "5" {
+		t.Fatalf("unexpected pending withdrawals: %+v", pending)
+	}
+}
@@ -0,0 +1,85 @@
+package funpay
+
+import "github.com/PuerkitoBio/goquery"
+
+// FormField represents field of the HTML form (see [ExtractForm]).
+type FormField struct {
+	Value string
+
+	// Variants contains values of select options, radio buttons or "on" for checkbox.
+	Variants []string
+
+	// Checkbox reports whether the field is checkbox. Value of checked checkbox is "on", unchecked is empty.
+	Checkbox bool
+}
+
+// ExtractForm extracts named inputs, textareas and selects of the form. [FormCSRFToken] is skipped,
+// select options with empty value are not included into variants.
+func ExtractForm(form *goquery.Selection) map[string]FormField {
+	fields := make(map[string]FormField)
+
+	form.Find("input[name]").Each(func(i int, s *goquery.Selection) {
+		name := s.AttrOr("name", "")
+		if name == FormCSRFToken {
+			return
+		}
+
+		switch s.AttrOr("type", "") {
+		case "checkbox":
+			field := FormField{
+				Variants: []string{"on"},
+				Checkbox: true,
+			}
+			if _, ok := s.Attr("checked"); ok {
+				field.Value = "on"
+			}
+
+			fields[name] = field
+
+		case "radio":
+			field := fields[name]
+			value := s.AttrOr("value", "")
+			field.Variants = append(field.Variants, value)
+			if _, ok := s.Attr("checked"); ok {
+				field.Value = value
+			}
+
+			fields[name] = field
+
+		default:
+			fields[name] = FormField{
+				Value: s.AttrOr("value", ""),
+			}
+		}
+	})
+
+	form.Find("textarea[name]").Each(func(i int, s *goquery.Selection) {
+		fields[s.AttrOr("name", "")] = FormField{
+			Value: s.Text(),
+		}
+	})
+
+	form.Find("select[name]").Each(func(i int, s *goquery.Selection) {
+		options := s.Find("option[value]")
+		field := FormField{
+			Variants: make([]string, 0, options.Length()),
+		}
+
+		options.Each(func(i int, option *goquery.Selection) {
+			value := option.AttrOr("value", "")
+			if value == "" {
+				return
+			}
+
+			field.Variants = append(field.Variants, value)
+
+			if _, ok := option.Attr("selected"); ok {
+				field.Value = value
+			}
+		})
+
+		fields[s.AttrOr("name", "")] = field
+	})
+
+	return fields
+}
@@ -0,0 +1,46 @@
+package funpay_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+)
+
+func TestExtractForm(t *testing.T) {
+	t.Parallel()
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`
+		<form>
+			<input type="hidden" name="csrf_token" value="secret">
+			<input type="text" name="title" value="Lot">
+			<input type="checkbox" name="active" checked>
+			<input type="checkbox" name="away">
+			<input type="radio" name="sound" value="0">
+			<input type="radio" name="sound" value="1" checked>
+			<textarea name="description">Text</textarea>
+			<select name="server">
+				<option value="">Choose</option>
+				<option value="eu" selected>EU</option>
+				<option value="us">US</option>
+			</select>
+		</form>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]funpay.FormField{
+		"title":       {Value: "Lot"},
+		"active":      {Value: "on", Variants: []string{"on"}, Checkbox: true},
+		"away":        {Variants: []string{"on"}, Checkbox: true},
+		"sound":       {Value: "1", Variants: []string{"0", "1"}},
+		"description": {Value: "Text"},
+		"server":      {Value: "eu", Variants: []string{"eu", "us"}},
+	}
+
+	if fields := funpay.ExtractForm(doc.Find("form")); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected %+v, got %+v", expected, fields)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/kostromin59/funpay"
@@ -467,8 +468,8 @@ func TestFunpay_RequestHTML(t *testing.T) {
 			t.Errorf("expected username 'testuser', got %q", fp.Username())
 		}
 
-		if fp.Balance() != 100 {
-			t.Errorf("expected balance 100, got %d", fp.Balance())
+		if expected := funpay.NewMoney(100, 0, funpay.CurrencyRUB); fp.Balance() != expected {
+			t.Errorf("expected balance %v, got %v", expected, fp.Balance())
 		}
 
 		if doc == nil {
@@ -476,6 +477,43 @@ func TestFunpay_RequestHTML(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid balance keeps previous balance", func(t *testing.T) {
+		t.Parallel()
+
+		var broken atomic.Bool
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			balance := "100 ₽"
+			if broken.Load() {
+				balance = "100 ¤"
+			}
+
+			fmt.Fprintf(w, `<html><body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'><div class="badge-balance">%s</div></body></html>`, balance)
+		}))
+		defer ts.Close()
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.SetBaseURL(ts.URL)
+
+		if _, err := fp.RequestHTML(t.Context(), ts.URL); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		broken.Store(true)
+
+		doc, err := fp.RequestHTML(t.Context(), ts.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if doc == nil {
+			t.Error("expected document, got nil")
+		}
+
+		if expected := funpay.NewMoney(100, 0, funpay.CurrencyRUB); fp.Balance() != expected {
+			t.Errorf("expected balance %v, got %v", expected, fp.Balance())
+		}
+	})
+
 	t.Run("missing app data", func(t *testing.T) {
 		t.Parallel()
 
@@ -586,8 +624,8 @@ func TestFunpay_RequestHTML(t *testing.T) {
 			t.Errorf("expected username 'updated_user', got %q", fp.Username())
 		}
 
-		if fp.Balance() != 200 {
-			t.Errorf("expected balance 200, got %d", fp.Balance())
+		if expected := funpay.NewMoney(200, 0, funpay.CurrencyRUB); fp.Balance() != expected {
+			t.Errorf("expected balance %v, got %v", expected, fp.Balance())
 		}
 
 		if fp.Locale() != funpay.LocaleEN {
@@ -698,3 +736,104 @@ func TestFunpay_UpdateLocale(t *testing.T) {
 		}
 	})
 }
+
+func TestFunpay_Status(t *testing.T) {
+	t.Parallel()
+
+	var unauthorized atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unauthorized.Load() {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		fmt.Fprint(w, `<html><body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'></body></html>`)
+	}))
+	defer ts.Close()
+
+	fp := funpay.New("test_key", "test_agent")
+	fp.SetBaseURL(ts.URL)
+
+	if status := fp.Status(); status.OK() || !status.LastUpdate.IsZero() {
+		t.Fatalf("expected empty status before update, got %+v", status)
+	}
+
+	if err := fp.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	success := fp.Status()
+	if !success.OK() || success.LastSuccess != success.LastUpdate {
+		t.Fatalf("expected successful status, got %+v", success)
+	}
+
+	unauthorized.Store(true)
+	if err := fp.Update(t.Context()); err == nil {
+		t.Fatal("expected error")
+	}
+
+	failed := fp.Status()
+	if failed.OK() || !errors.Is(failed.Err, funpay.ErrAccountUnauthorized) {
+		t.Errorf("expected failed status, got %+v", failed)
+	}
+
+	if failed.LastSuccess != success.LastSuccess || failed.LastUpdate.Before(success.LastUpdate) {
+		t.Errorf("unexpected update times: %+v", failed)
+	}
+}
+
+func TestFunpay_UpdateCurrency(t *testing.T) {
+	t.Parallel()
+	t.Run("successful currency update", func(t *testing.T) {
+		t.Parallel()
+
+		var switched atomic.Bool
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				if r.URL.Path != "/account/switchCurrency" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				if err := r.ParseForm(); err != nil || r.PostForm.Get("cy") != "usd" || r.PostForm.Get("csrf_token") != "test" {
+					t.Errorf("unexpected form: %v", r.PostForm)
+				}
+
+				switched.Store(true)
+				return
+			}
+
+			balance := "100 ₽"
+			if switched.Load() {
+				balance = "1.5 $"
+			}
+
+			fmt.Fprintf(w, `<html><body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'><span class="badge-balance">%s</span></body></html>`, balance)
+		}))
+		defer ts.Close()
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.SetBaseURL(ts.URL)
+
+		if err := fp.Update(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := fp.UpdateCurrency(t.Context(), funpay.CurrencyUSD); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if balance := fp.Balance(); balance.Currency != funpay.CurrencyUSD || balance.Amount != 150 {
+			t.Errorf("expected balance in USD, got %v", balance)
+		}
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		t.Parallel()
+
+		fp := funpay.New("test_key", "test_agent")
+
+		if err := fp.UpdateCurrency(t.Context(), "BTC"); !errors.Is(err, funpay.ErrUnsupportedCurrency) {
+			t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+		}
+	})
+}
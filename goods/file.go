@@ -0,0 +1,295 @@
+package goods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kostromin59/funpay/lots"
+)
+
+// FileSource is [GoodsSource] backed by text file with one item per line.
+// Consumed items are removed from the file atomically (write to temporary file and rename).
+type FileSource struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSource creates [FileSource] for provided path. File may not exist yet, it will be treated as empty.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{
+		path: path,
+	}
+}
+
+func (s *FileSource) Peek(ctx context.Context, n int) ([]string, error) {
+	const op = "FileSource.Peek"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := s.read()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n = min(max(n, 0), len(items))
+
+	return items[:n], nil
+}
+
+func (s *FileSource) Consume(ctx context.Context, items []string) error {
+	const op = "FileSource.Consume"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.read()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.write(consume(current, items)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *FileSource) Return(ctx context.Context, items []string) error {
+	const op = "FileSource.Return"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.read()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.write(slices.Concat(items, current)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *FileSource) Len(ctx context.Context) (int, error) {
+	const op = "FileSource.Len"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := s.read()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(items), nil
+}
+
+func (s *FileSource) read() ([]string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return lots.ParseSecrets(string(data)), nil
+}
+
+func (s *FileSource) write(items []string) error {
+	return writeFileAtomic(s.path, []byte(lots.JoinSecrets(items)))
+}
+
+// DirSource is [GoodsSource] backed by directory where every regular file contains a single item.
+// Files are taken in lexical order, consumed items are deleted from the directory.
+// Returned items are written into new files named "0-returned-*", so they are usually taken first.
+type DirSource struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewDirSource creates [DirSource] for provided directory.
+func NewDirSource(dir string) *DirSource {
+	return &DirSource{
+		dir: dir,
+	}
+}
+
+func (s *DirSource) Peek(ctx context.Context, n int) ([]string, error) {
+	const op = "DirSource.Peek"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := s.files()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	items := make([]string, 0, min(max(n, 0), len(files)))
+	for _, file := range files {
+		if len(items) >= n {
+			break
+		}
+
+		item, err := s.read(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if item == "" {
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (s *DirSource) Consume(ctx context.Context, items []string) error {
+	const op = "DirSource.Consume"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remove := make(map[string]int, len(items))
+	for _, item := range items {
+		remove[item]++
+	}
+
+	files, err := s.files()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, file := range files {
+		item, err := s.read(file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if remove[item] == 0 {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(s.dir, file)); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		remove[item]--
+	}
+
+	return nil
+}
+
+func (s *DirSource) Return(ctx context.Context, items []string) error {
+	const op = "DirSource.Return"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range items {
+		file, err := os.CreateTemp(s.dir, "0-returned-*")
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = file.WriteString(item)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			os.Remove(file.Name())
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *DirSource) Len(ctx context.Context) (int, error) {
+	const op = "DirSource.Len"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := s.files()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var n int
+	for _, file := range files {
+		item, err := s.read(file)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if item != "" {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (s *DirSource) files() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		files = append(files, entry.Name())
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+func (s *DirSource) read(file string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, file))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeFileAtomic writes data into temporary file in the same directory and renames it to path.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
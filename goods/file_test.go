@@ -0,0 +1,145 @@
+package goods_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kostromin59/funpay/goods"
+)
+
+func TestFileSource(t *testing.T) {
+	t.Parallel()
+	t.Run("peek and consume", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "goods.txt")
+		if err := os.WriteFile(path, []byte("a\n\nb\nc\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		source := goods.NewFileSource(path)
+
+		items, err := source.Peek(t.Context(), 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if expected := []string{"a", "b"}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected %v, got %v", expected, items)
+		}
+
+		if err := source.Consume(t.Context(), items); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != "c" {
+			t.Errorf("expected file content %q, got %q", "c", string(data))
+		}
+
+		if err := source.Return(t.Context(), []string{"b"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		items, err = source.Peek(t.Context(), 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if expected := []string{"b", "c"}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected %v, got %v", expected, items)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		source := goods.NewFileSource(filepath.Join(t.TempDir(), "missing.txt"))
+
+		n, err := source.Len(t.Context())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if n != 0 {
+			t.Errorf("expected empty source, got %d", n)
+		}
+	})
+}
+
+func TestDirSource(t *testing.T) {
+	t.Parallel()
+	t.Run("peek and consume", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		files := map[string]string{
+			"1.txt":   "first\n",
+			"2.txt":   "second",
+			"3.txt":   "",
+			".hidden": "hidden",
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		source := goods.NewDirSource(dir)
+
+		items, err := source.Peek(t.Context(), 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if expected := []string{"first", "second"}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected %v, got %v", expected, items)
+		}
+
+		if err := source.Consume(t.Context(), []string{"first"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "1.txt")); !os.IsNotExist(err) {
+			t.Errorf("expected consumed file to be removed, got %v", err)
+		}
+
+		n, err := source.Len(t.Context())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if n != 1 {
+			t.Errorf("expected 1 item, got %d", n)
+		}
+
+		if err := source.Return(t.Context(), []string{"first"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		items, err = source.Peek(t.Context(), 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if expected := []string{"first", "second"}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected %v, got %v", expected, items)
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		t.Parallel()
+
+		source := goods.NewDirSource(filepath.Join(t.TempDir(), "missing"))
+
+		if _, err := source.Peek(t.Context(), 1); err == nil {
+			t.Error("expected error for missing directory")
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package goods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/lots"
+)
+
+// soldLimit is the maximum count of remembered sold items, the oldest ones are forgotten first.
+const soldLimit = 10000
+
+var (
+	// ErrInvalidRule indicates that [Rule] has no source or thresholds are incorrect.
+	ErrInvalidRule = errors.New("invalid rule")
+)
+
+// Rule describes how to replenish auto-delivery goods of the offer.
+//
+// When count of secrets falls below Min, items are taken from Source until count reaches Max.
+type Rule struct {
+	OfferID lots.OfferID
+	Source  GoodsSource
+	Min     int
+	Max     int
+}
+
+func (r Rule) validate() error {
+	if r.OfferID == "" || r.Source == nil || r.Max <= 0 || r.Min > r.Max {
+		return fmt.Errorf("%w (offer %q, min %d, max %d)", ErrInvalidRule, r.OfferID, r.Min, r.Max)
+	}
+
+	return nil
+}
+
+// Replenisher keeps count of offer secrets between [Rule] thresholds.
+//
+// Items are consumed from the [GoodsSource] only after the offer was saved.
+// Items which were sold (disappeared from the offer since the previous check) are consumed
+// from the source right away, so duplicates are not listed again after restart.
+// Items which are already in the offer or were sold recently are skipped and removed from the source.
+type Replenisher struct {
+	lots  lots.Lots
+	rules []Rule
+
+	known map[lots.OfferID][]string
+	sold  *recentSet
+	mu    sync.Mutex
+}
+
+// NewReplenisher creates [Replenisher] for provided rules. Returns [ErrInvalidRule] if any rule is invalid.
+func NewReplenisher(l lots.Lots, rules ...Rule) (*Replenisher, error) {
+	const op = "NewReplenisher"
+
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return &Replenisher{
+		lots:  l,
+		rules: rules,
+		known: make(map[lots.OfferID][]string),
+		sold:  newRecentSet(soldLimit),
+	}, nil
+}
+
+// Replenish checks every rule once. A failed offer does not stop replenishing the others: errors of all failed rules
+// are joined and returned.
+func (r *Replenisher) Replenish(ctx context.Context) error {
+	const op = "Replenisher.Replenish"
+
+	var errs []error
+	for _, rule := range r.rules {
+		if _, err := r.ReplenishOffer(ctx, rule); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReplenishOffer checks single rule. Returns count of items added into the offer.
+func (r *Replenisher) ReplenishOffer(ctx context.Context, rule Rule) (int, error) {
+	const op = "Replenisher.ReplenishOffer"
+
+	if err := rule.validate(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	secrets, err := r.lots.Secrets(ctx, rule.OfferID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := r.markSold(ctx, rule, secrets); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(secrets) >= rule.Min {
+		return 0, nil
+	}
+
+	fresh, skipped, err := r.take(ctx, rule.Source, secrets, rule.Max-len(secrets))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(fresh) != 0 {
+		if err := r.lots.AddSecrets(ctx, rule.OfferID, fresh...); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		r.known[rule.OfferID] = slices.Concat(secrets, fresh)
+	}
+
+	consumed := slices.Concat(fresh, skipped)
+	if len(consumed) == 0 {
+		return 0, nil
+	}
+
+	if err := rule.Source.Consume(ctx, consumed); err != nil {
+		return len(fresh), fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(fresh), nil
+}
+
+// Run replenishes offers every interval until context is done, so a sold item is replaced in at most one interval.
+// Errors of [Replenisher.Replenish] are passed to onError if it is not nil. Returns [funpay.ErrInvalidInterval]
+// if interval is not positive.
+func (r *Replenisher) Run(ctx context.Context, interval time.Duration, onError func(err error)) error {
+	return funpay.RunEvery(ctx, interval, r.Replenish, onError)
+}
+
+// markSold remembers items which were in the offer at the previous check but are missing now
+// and consumes their duplicates from the source.
+func (r *Replenisher) markSold(ctx context.Context, rule Rule, secrets []string) error {
+	var sold []string
+	for _, item := range r.known[rule.OfferID] {
+		if !slices.Contains(secrets, item) {
+			sold = append(sold, item)
+		}
+	}
+
+	if len(sold) != 0 {
+		if err := rule.Source.Consume(ctx, sold); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range sold {
+		r.sold.add(item)
+	}
+
+	r.known[rule.OfferID] = secrets
+
+	return nil
+}
+
+// take peeks up to need items from the source which are neither in the offer nor sold.
+// Skipped items should be consumed as well since they are duplicates.
+func (r *Replenisher) take(ctx context.Context, source GoodsSource, secrets []string, need int) (fresh, skipped []string, err error) {
+	limit := need
+	for {
+		items, err := source.Peek(ctx, limit)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		seen := make(map[string]struct{}, len(secrets)+len(items))
+		for _, secret := range secrets {
+			seen[secret] = struct{}{}
+		}
+
+		fresh, skipped = fresh[:0], skipped[:0]
+		for _, item := range items {
+			if len(fresh) == need {
+				break
+			}
+
+			_, isSeen := seen[item]
+			isSold := r.sold.contains(item)
+			if isSeen || isSold {
+				skipped = append(skipped, item)
+				continue
+			}
+
+			seen[item] = struct{}{}
+			fresh = append(fresh, item)
+		}
+
+		if len(fresh) == need || len(items) < limit {
+			return fresh, skipped, nil
+		}
+
+		limit += need - len(fresh)
+	}
+}
+
+// recentSet is a set which keeps up to limit the most recently added items.
+type recentSet struct {
+	items map[string]struct{}
+	order []string
+	limit int
+}
+
+func newRecentSet(limit int) *recentSet {
+	return &recentSet{
+		items: make(map[string]struct{}),
+		limit: limit,
+	}
+}
+
+func (s *recentSet) add(item string) {
+	if _, ok := s.items[item]; ok {
+		return
+	}
+
+	s.items[item] = struct{}{}
+	s.order = append(s.order, item)
+
+	if len(s.order) > s.limit {
+		delete(s.items, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+func (s *recentSet) contains(item string) bool {
+	_, ok := s.items[item]
+	return ok
+}
@@ -0,0 +1,192 @@
+package goods_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kostromin59/funpay/goods"
+	"github.com/kostromin59/funpay/lots"
+	"github.com/kostromin59/funpay/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewReplenisher(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := goods.NewReplenisher(mocks.NewMockLots(ctrl), goods.Rule{
+		OfferID: "1",
+		Source:  goods.NewMemorySource(),
+		Min:     10,
+		Max:     5,
+	})
+	if !errors.Is(err, goods.ErrInvalidRule) {
+		t.Fatalf("expected ErrInvalidRule, got %v", err)
+	}
+}
+
+func TestReplenisher_ReplenishOffer(t *testing.T) {
+	t.Parallel()
+	t.Run("fills offer up to max", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpLots := mocks.NewMockLots(ctrl)
+		source := goods.NewMemorySource("a", "b", "c", "d", "e")
+		rule := goods.Rule{OfferID: "1", Source: source, Min: 2, Max: 4}
+
+		r, err := goods.NewReplenisher(fpLots, rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fpLots.EXPECT().Secrets(t.Context(), lots.OfferID("1")).Times(1).Return([]string{"a"}, nil)
+		fpLots.EXPECT().AddSecrets(t.Context(), gomock.Any(), "b", "c", "d").Times(1).Return(nil)
+
+		added, err := r.ReplenishOffer(t.Context(), rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if added != 3 {
+			t.Errorf("expected 3 added items, got %d", added)
+		}
+
+		items, _ := source.Peek(t.Context(), 10)
+		if expected := []string{"e"}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected %v left in source, got %v", expected, items)
+		}
+	})
+
+	t.Run("skips sold items", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpLots := mocks.NewMockLots(ctrl)
+		source := goods.NewMemorySource()
+		rule := goods.Rule{OfferID: "1", Source: source, Min: 1, Max: 2}
+
+		r, err := goods.NewReplenisher(fpLots, rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		gomock.InOrder(
+			fpLots.EXPECT().Secrets(t.Context(), gomock.Any()).Times(1).Return([]string{"sold", "x"}, nil),
+			fpLots.EXPECT().Secrets(t.Context(), gomock.Any()).Times(1).Return([]string{}, nil),
+			fpLots.EXPECT().AddSecrets(t.Context(), gomock.Any(), "fresh", "y").Times(1).Return(nil),
+		)
+
+		if _, err := r.ReplenishOffer(t.Context(), rule); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		source.Add("sold", "fresh", "x", "y")
+
+		added, err := r.ReplenishOffer(t.Context(), rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if added != 2 {
+			t.Errorf("expected 2 added items, got %d", added)
+		}
+
+		if n, _ := source.Len(t.Context()); n != 0 {
+			t.Errorf("expected source to be empty, got %d items", n)
+		}
+	})
+
+	t.Run("consumes sold items from source", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpLots := mocks.NewMockLots(ctrl)
+		source := goods.NewMemorySource("sold", "fresh")
+		rule := goods.Rule{OfferID: "1", Source: source, Min: 1, Max: 1}
+
+		r, err := goods.NewReplenisher(fpLots, rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		gomock.InOrder(
+			fpLots.EXPECT().Secrets(t.Context(), gomock.Any()).Times(1).Return([]string{"sold"}, nil),
+			fpLots.EXPECT().Secrets(t.Context(), gomock.Any()).Times(1).Return([]string{"other"}, nil),
+		)
+
+		for range 2 {
+			if _, err := r.ReplenishOffer(t.Context(), rule); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		items, _ := source.Peek(t.Context(), 10)
+		if expected := []string{"fresh"}; !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected %v left in source, got %v", expected, items)
+		}
+	})
+
+	t.Run("keeps items when save fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpLots := mocks.NewMockLots(ctrl)
+		source := goods.NewMemorySource("a", "b")
+		rule := goods.Rule{OfferID: "1", Source: source, Min: 1, Max: 2}
+
+		r, err := goods.NewReplenisher(fpLots, rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fpLots.EXPECT().Secrets(t.Context(), gomock.Any()).Times(1).Return(nil, nil)
+		fpLots.EXPECT().AddSecrets(t.Context(), gomock.Any(), "a", "b").Times(1).Return(errors.New("save error"))
+
+		if _, err := r.ReplenishOffer(t.Context(), rule); err == nil {
+			t.Fatal("expected error when save fails")
+		}
+
+		if n, _ := source.Len(t.Context()); n != 2 {
+			t.Errorf("expected items to stay in source, got %d items", n)
+		}
+	})
+
+	t.Run("enough secrets", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fpLots := mocks.NewMockLots(ctrl)
+		source := goods.NewMemorySource("a")
+		rule := goods.Rule{OfferID: "1", Source: source, Min: 1, Max: 2}
+
+		r, err := goods.NewReplenisher(fpLots, rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fpLots.EXPECT().Secrets(t.Context(), gomock.Any()).Times(1).Return([]string{"z"}, nil)
+
+		added, err := r.ReplenishOffer(t.Context(), rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if added != 0 {
+			t.Errorf("expected nothing to be added, got %d", added)
+		}
+	})
+}
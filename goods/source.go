@@ -0,0 +1,98 @@
+package goods
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// GoodsSource represents storage of goods for auto-delivery (one item per secret).
+//
+// Items should be taken in two steps: [GoodsSource.Peek] to get items without
+// removing them and [GoodsSource.Consume] after items were delivered (saved into
+// the lot or sent to the buyer). It guarantees that items are not lost when delivery fails.
+// Items consumed in advance (reserved) are put back with [GoodsSource.Return] if delivery fails.
+type GoodsSource interface {
+	// Peek returns up to n first items without removing them from the source.
+	// Returns empty slice if source is empty.
+	Peek(ctx context.Context, n int) ([]string, error)
+
+	// Consume removes provided items from the source. Unknown items are ignored.
+	Consume(ctx context.Context, items []string) error
+
+	// Return puts items back to the beginning of the source.
+	Return(ctx context.Context, items []string) error
+
+	// Len returns count of items in the source.
+	Len(ctx context.Context) (int, error)
+}
+
+// MemorySource is in-memory implementation of [GoodsSource].
+type MemorySource struct {
+	items []string
+	mu    sync.Mutex
+}
+
+// NewMemorySource creates [MemorySource] filled with provided items.
+func NewMemorySource(items ...string) *MemorySource {
+	return &MemorySource{
+		items: slices.Clone(items),
+	}
+}
+
+// Add appends items to the end of the source.
+func (s *MemorySource) Add(items ...string) {
+	s.mu.Lock()
+	s.items = append(s.items, items...)
+	s.mu.Unlock()
+}
+
+func (s *MemorySource) Peek(ctx context.Context, n int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n = min(max(n, 0), len(s.items))
+
+	return slices.Clone(s.items[:n]), nil
+}
+
+func (s *MemorySource) Consume(ctx context.Context, items []string) error {
+	s.mu.Lock()
+	s.items = consume(s.items, items)
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *MemorySource) Return(ctx context.Context, items []string) error {
+	s.mu.Lock()
+	s.items = slices.Concat(items, s.items)
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *MemorySource) Len(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	n := len(s.items)
+	s.mu.Unlock()
+
+	return n, nil
+}
+
+// consume removes one occurrence of every item from the list.
+func consume(list []string, items []string) []string {
+	remove := make(map[string]int, len(items))
+	for _, item := range items {
+		remove[item]++
+	}
+
+	return slices.DeleteFunc(list, func(item string) bool {
+		if remove[item] == 0 {
+			return false
+		}
+
+		remove[item]--
+		return true
+	})
+}
@@ -0,0 +1,59 @@
+package goods_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kostromin59/funpay/goods"
+)
+
+func TestMemorySource(t *testing.T) {
+	t.Parallel()
+
+	source := goods.NewMemorySource("a", "b", "c")
+	source.Add("d")
+
+	items, err := source.Peek(t.Context(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected %v, got %v", expected, items)
+	}
+
+	if err := source.Consume(t.Context(), []string{"b", "unknown"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, err = source.Peek(t.Context(), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := []string{"a", "c", "d"}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected %v, got %v", expected, items)
+	}
+
+	n, err := source.Len(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n != 3 {
+		t.Errorf("expected 3 items, got %d", n)
+	}
+
+	if err := source.Return(t.Context(), []string{"b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, err = source.Peek(t.Context(), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := []string{"b", "a", "c", "d"}; !reflect.DeepEqual(items, expected) {
+		t.Errorf("expected %v, got %v", expected, items)
+	}
+}
@@ -1,5 +1,15 @@
 package funpay
 
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrUnsupportedLocale indicates that locale is not registered (see [RegisterLocale]).
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+)
+
 // Locale represents the Funpay webiste locale.
 type Locale string
 
@@ -7,3 +17,58 @@ const (
 	LocaleRU Locale = "ru"
 	LocaleEN Locale = "en"
 )
+
+var (
+	// localePrefixes contains path prefixes of registered locales. Default locale has empty prefix.
+	localePrefixes = map[Locale]string{
+		LocaleRU: "",
+		LocaleEN: string(LocaleEN),
+	}
+	localesMu sync.RWMutex
+)
+
+// RegisterLocale registers locale supported by Funpay, e.g. RegisterLocale("uk", "uk").
+// Pages of the locale are requested with the path prefix (/uk/lots/); empty prefix means the default locale.
+func RegisterLocale(locale Locale, prefix string) {
+	localesMu.Lock()
+	localePrefixes[locale] = prefix
+	localesMu.Unlock()
+}
+
+// Valid reports whether the locale is registered (see [RegisterLocale]).
+func (l Locale) Valid() bool {
+	localesMu.RLock()
+	_, ok := localePrefixes[l]
+	localesMu.RUnlock()
+
+	return ok
+}
+
+// PathPrefix returns path prefix of the locale without slashes. Returns empty string for
+// the default and unknown locales.
+func (l Locale) PathPrefix() string {
+	localesMu.RLock()
+	prefix := localePrefixes[l]
+	localesMu.RUnlock()
+
+	return prefix
+}
+
+// LocaleByPrefix returns locale by path prefix, e.g. [LocaleEN] for "en".
+// Returns false if prefix is empty or does not belong to registered locale.
+func LocaleByPrefix(prefix string) (Locale, bool) {
+	if prefix == "" {
+		return "", false
+	}
+
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	for locale, p := range localePrefixes {
+		if p == prefix {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,51 @@
+package funpay
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Redacted replaces values of sensitive headers, cookies and form fields in logs.
+const Redacted = "[REDACTED]"
+
+// sensitiveHeaders contains canonical names of headers which must not be logged.
+var sensitiveHeaders = []string{"Cookie", "Set-Cookie", "Authorization", "Proxy-Authorization"}
+
+// discardLogger is returned by [FunpayClient.Logger] if logger is not set.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// headersAttr returns headers as log group with redacted sensitive values.
+func headersAttr(key string, headers http.Header) slog.Attr {
+	attrs := make([]any, 0, len(headers))
+	for name, values := range headers {
+		value := strings.Join(values, ", ")
+		if slices.Contains(sensitiveHeaders, http.CanonicalHeaderKey(name)) {
+			value = Redacted
+		}
+
+		attrs = append(attrs, slog.String(name, value))
+	}
+
+	return slog.Group(key, attrs...)
+}
+
+// urlAttr returns URL without password and with redacted golden key and CSRF token query params.
+func urlAttr(key string, u *url.URL) slog.Attr {
+	if u == nil {
+		return slog.String(key, "")
+	}
+
+	redacted := *u
+	q := redacted.Query()
+	for _, name := range []string{CookieGoldenKey, FormCSRFToken} {
+		if q.Has(name) {
+			q.Set(name, Redacted)
+			redacted.RawQuery = q.Encode()
+		}
+	}
+
+	return slog.String(key, redacted.Redacted())
+}
@@ -0,0 +1,83 @@
+package funpay_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+func TestFunpay_Logger(t *testing.T) {
+	t.Parallel()
+	t.Run("discards by default", func(t *testing.T) {
+		t.Parallel()
+
+		fp := funpay.New("test_key", "test_agent")
+		if fp.Logger() == nil {
+			t.Fatal("expected logger, got nil")
+		}
+	})
+
+	t.Run("logs requests with redacted secrets", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.SetCookie(w, &http.Cookie{Name: "PHPSESSID", Value: "session_secret"})
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer ts.Close()
+
+		var buf bytes.Buffer
+		fp := funpay.New("golden_secret", "test_agent")
+		fp.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+		if _, err := fp.Request(t.Context(), ts.URL+"/lots/?csrf_token=csrf_secret", funpay.RequestWithMethod(http.MethodPost)); err == nil {
+			t.Fatal("expected error")
+		}
+
+		for _, secret := range []string{"golden_secret", "session_secret", "csrf_secret"} {
+			if strings.Contains(buf.String(), secret) {
+				t.Errorf("log contains secret %q: %s", secret, buf.String())
+			}
+		}
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid log entry: %v", err)
+		}
+
+		if entry["level"] != "WARN" || entry["method"] != http.MethodPost || entry["status"] != float64(http.StatusTooManyRequests) {
+			t.Errorf("unexpected log entry: %v", entry)
+		}
+
+		if _, ok := entry["duration"]; !ok {
+			t.Errorf("expected duration in log entry: %v", entry)
+		}
+
+		headers, _ := entry["request_headers"].(map[string]any)
+		if headers["Cookie"] != funpay.Redacted || headers["User-Agent"] != "test_agent" {
+			t.Errorf("unexpected request headers: %v", headers)
+		}
+	})
+
+	t.Run("logs connection errors", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		fp := funpay.New("test_key", "test_agent")
+		fp.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+		if _, err := fp.Request(t.Context(), "http://127.0.0.1:0/"); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if !strings.Contains(buf.String(), "funpay request failed") {
+			t.Errorf("expected failed request in log: %s", buf.String())
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -44,10 +45,12 @@ type Lots interface {
 	Save(ctx context.Context, fields Fields) error
 
 	// Fields loads [Fields] for [OfferID]. Values will be filled with provided offerID.
-	FieldsByOfferID(ctx context.Context, offerID OfferID) (Fields, error)
+	// Opts are passed to the request, e.g. [funpay.RequestWithLocale] to load the page in another locale.
+	FieldsByOfferID(ctx context.Context, offerID OfferID, opts ...funpay.RequestOpt) (Fields, error)
 
 	// FieldsByNodeID loads [Fields] for [NodeID].
-	FieldsByNodeID(ctx context.Context, nodeID NodeID) (Fields, error)
+	// Opts are passed to the request, e.g. [funpay.RequestWithLocale] to load the page in another locale.
+	FieldsByNodeID(ctx context.Context, nodeID NodeID, opts ...funpay.RequestOpt) (Fields, error)
 
 	// ByUser gets lots for provided userID. Key represents nodeID, value represents slice of offerIDs.
 	ByUser(ctx context.Context, userID int64) (map[NodeID][]OfferID, error)
@@ -58,6 +61,22 @@ type Lots interface {
 
 	// List returns loaded lots (see [Lots.Update]).
 	List() map[NodeID][]OfferID
+
+	// Secrets returns auto-delivery goods of the offer (see [Fields.Secrets]).
+	Secrets(ctx context.Context, offerID OfferID) ([]string, error)
+
+	// AddSecrets appends secrets to the end of auto-delivery goods and saves the offer.
+	AddSecrets(ctx context.Context, offerID OfferID, secrets ...string) error
+
+	// RemoveSecrets removes every occurrence of provided secrets from auto-delivery goods and saves the offer.
+	RemoveSecrets(ctx context.Context, offerID OfferID, secrets ...string) error
+
+	// ReplaceSecrets overwrites auto-delivery goods with provided secrets and saves the offer.
+	ReplaceSecrets(ctx context.Context, offerID OfferID, secrets []string) error
+
+	// LowStock checks offers from [Lots.List] with enabled auto-delivery.
+	// Returns offers which have less than threshold secrets. Value represents count of secrets.
+	LowStock(ctx context.Context, threshold int) (map[OfferID]int, error)
 }
 
 type LotsClient struct {
@@ -73,9 +92,21 @@ func New(fp funpay.Funpay) Lots {
 	}
 }
 
-func (l *LotsClient) Save(ctx context.Context, fields Fields) error {
+// logger returns logger of the client (see [funpay.FunpayRequester.Logger]).
+func (l *LotsClient) logger() *slog.Logger {
+	return l.fp.Logger().With(slog.String("module", "lots"))
+}
+
+func (l *LotsClient) Save(ctx context.Context, fields Fields) (err error) {
 	const op = "LotsClient.Save"
 
+	ctx, span := l.fp.Tracer().Start(ctx, "lots.Save",
+		slog.String("op", op),
+		slog.String("offer_id", fields[FieldOfferID].Value),
+		slog.String("node_id", fields[FieldNodeID].Value),
+	)
+	defer func() { span.End(err) }()
+
 	body := url.Values{}
 
 	for name, v := range fields {
@@ -85,7 +116,7 @@ func (l *LotsClient) Save(ctx context.Context, fields Fields) error {
 	body.Set(funpay.FormCSRFToken, l.fp.CSRFToken())
 	body.Set("location", "trade")
 
-	_, err := l.fp.Request(ctx, l.fp.BaseURL()+"/lots/offerSave",
+	_, err = l.fp.Request(ctx, funpay.URL("lots", "offerSave").WithBaseURL(l.fp.BaseURL()).String(),
 		funpay.RequestWithMethod(http.MethodPost),
 		funpay.RequestWithBody(bytes.NewBufferString(body.Encode())),
 		funpay.RequestWithHeaders(funpay.RequestPostHeaders),
@@ -94,13 +125,21 @@ func (l *LotsClient) Save(ctx context.Context, fields Fields) error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	l.logger().LogAttrs(ctx, slog.LevelInfo, "lot saved",
+		slog.String("offer_id", fields[FieldOfferID].Value),
+		slog.Bool("deleted", fields["deleted"].Value == "1"),
+	)
+
 	return nil
 }
 
-func (l *LotsClient) FieldsByOfferID(ctx context.Context, offerID OfferID) (Fields, error) {
+func (l *LotsClient) FieldsByOfferID(ctx context.Context, offerID OfferID, opts ...funpay.RequestOpt) (_ Fields, err error) {
 	const op = "LotsClient.FieldsByOfferID"
 
-	fields, err := l.fields(ctx, "", offerID)
+	ctx, span := l.fp.Tracer().Start(ctx, "lots.FieldsByOfferID", slog.String("op", op), slog.String("offer_id", string(offerID)))
+	defer func() { span.End(err) }()
+
+	fields, err := l.fields(ctx, "", offerID, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -108,10 +147,13 @@ func (l *LotsClient) FieldsByOfferID(ctx context.Context, offerID OfferID) (Fiel
 	return fields, nil
 }
 
-func (l *LotsClient) FieldsByNodeID(ctx context.Context, nodeID NodeID) (Fields, error) {
-	const op = "LotsClient.FieldsByOfferID"
+func (l *LotsClient) FieldsByNodeID(ctx context.Context, nodeID NodeID, opts ...funpay.RequestOpt) (_ Fields, err error) {
+	const op = "LotsClient.FieldsByNodeID"
+
+	ctx, span := l.fp.Tracer().Start(ctx, "lots.FieldsByNodeID", slog.String("op", op), slog.String("node_id", string(nodeID)))
+	defer func() { span.End(err) }()
 
-	fields, err := l.fields(ctx, nodeID, "")
+	fields, err := l.fields(ctx, nodeID, "", opts...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -119,26 +161,23 @@ func (l *LotsClient) FieldsByNodeID(ctx context.Context, nodeID NodeID) (Fields,
 	return fields, nil
 }
 
-func (l *LotsClient) fields(ctx context.Context, nodeID NodeID, offerID OfferID) (Fields, error) {
+func (l *LotsClient) fields(ctx context.Context, nodeID NodeID, offerID OfferID, opts ...funpay.RequestOpt) (Fields, error) {
 	const op = "LotsClient.fields"
 
-	reqURL, err := url.Parse(l.fp.BaseURL())
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-
-	reqURL = reqURL.JoinPath("lots", "offerEdit")
-
-	q := reqURL.Query()
+	builder := funpay.URL("lots", "offerEdit").WithBaseURL(l.fp.BaseURL())
 	if offerID != "" {
-		q.Set("offer", string(offerID))
+		builder.WithQuery("offer", string(offerID))
 	}
 	if nodeID != "" {
-		q.Set("node", string(nodeID))
+		builder.WithQuery("node", string(nodeID))
 	}
-	reqURL.RawQuery = q.Encode()
 
-	doc, err := l.fp.RequestHTML(ctx, reqURL.String())
+	reqURL, err := builder.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := l.fp.RequestHTML(ctx, reqURL.String(), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -148,100 +187,33 @@ func (l *LotsClient) fields(ctx context.Context, nodeID NodeID, offerID OfferID)
 
 func (l *LotsClient) extractFields(doc *goquery.Document) Fields {
 	fields := make(Fields)
-	form := doc.Find("form")
-	form.Find("input[name]").Each(func(i int, s *goquery.Selection) {
-		name, ok := s.Attr("name")
-		if !ok {
-			return
-		}
-
-		switch s.AttrOr("type", "") {
-		case "checkbox":
-			field := Field{
-				Variants: []string{"on"},
-			}
-			_, ok := s.Attr("checked")
-			if ok {
-				field.Value = "on"
-			}
-
-			fields[FieldKey(name)] = field
-
-		default:
-			if name == funpay.FormCSRFToken {
-				return
-			}
-
-			value := s.AttrOr("value", "")
-			fields[FieldKey(name)] = Field{
-				Value: value,
-			}
-		}
-	})
-
-	form.Find("textarea[name]").Each(func(i int, s *goquery.Selection) {
-		name, ok := s.Attr("name")
-		if !ok {
-			return
-		}
-
-		value := s.Text()
+	for name, field := range funpay.ExtractForm(doc.Find("form")) {
 		fields[FieldKey(name)] = Field{
-			Value: value,
+			Value:    field.Value,
+			Variants: field.Variants,
 		}
-	})
-
-	form.Find("select[name]").Each(func(i int, s *goquery.Selection) {
-		name, ok := s.Attr("name")
-		if !ok {
-			return
-		}
-
-		field := Field{}
-
-		opts := s.Find("option[value]")
-		variants := make([]string, 0, opts.Length())
-		opts.Each(func(i int, s *goquery.Selection) {
-			value, ok := s.Attr("value")
-			if !ok {
-				return
-			}
-
-			if value == "" {
-				return
-			}
-
-			variants = append(variants, value)
-
-			if _, ok := s.Attr("selected"); ok {
-				field.Value = value
-			}
-		})
-
-		field.Variants = variants
-
-		fields[FieldKey(name)] = field
-	})
+	}
 
 	return fields
 }
 
-func (l *LotsClient) ByUser(ctx context.Context, userID int64) (map[NodeID][]OfferID, error) {
+func (l *LotsClient) ByUser(ctx context.Context, userID int64) (_ map[NodeID][]OfferID, err error) {
 	const op = "LotsClient.ByUser"
 
-	reqURL, err := url.Parse(l.fp.BaseURL())
+	ctx, span := l.fp.Tracer().Start(ctx, "lots.ByUser", slog.String("op", op), slog.Int64("user_id", userID))
+	defer func() { span.End(err) }()
+
+	reqURL, err := funpay.URL("users", fmt.Sprintf("%d", userID), "/").WithBaseURL(l.fp.BaseURL()).Parse()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	reqURL = reqURL.JoinPath("users", fmt.Sprintf("%d", userID), "/")
-
 	doc, err := l.fp.RequestHTML(ctx, reqURL.String())
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	lots, err := l.extractLots(doc)
+	lots, err := ExtractLots(doc)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -249,8 +221,28 @@ func (l *LotsClient) ByUser(ctx context.Context, userID int64) (map[NodeID][]Off
 	return lots, nil
 }
 
-func (l *LotsClient) extractLots(doc *goquery.Document) (map[NodeID][]OfferID, error) {
-	const op = "LotsClient.extractLots"
+// ParseNodeLink parses links like /lots/{nodeID}/ and /chips/{nodeID}/ (chips is true). Locale prefix is ignored.
+func ParseNodeLink(href string) (nodeID NodeID, chips bool, ok bool) {
+	for _, section := range []string{"lots", "chips"} {
+		id, ok := funpay.LinkID(href, section)
+		if !ok {
+			continue
+		}
+
+		if strings.Trim(id, "0123456789") != "" {
+			return "", false, false
+		}
+
+		return NodeID(id), section == "chips", true
+	}
+
+	return "", false, false
+}
+
+// ExtractLots extracts offers of the user profile page (/users/{id}/).
+// Key represents nodeID, value represents slice of offerIDs.
+func ExtractLots(doc *goquery.Document) (map[NodeID][]OfferID, error) {
+	const op = "ExtractLots"
 
 	lots := make(map[NodeID][]OfferID)
 
@@ -261,13 +253,12 @@ func (l *LotsClient) extractLots(doc *goquery.Document) (map[NodeID][]OfferID, e
 			continue
 		}
 
-		nodeURL, err := url.Parse(nodeHref)
-		if err != nil {
+		if _, err := url.Parse(nodeHref); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
-		pathComponents := strings.Split(nodeURL.Path, "/")
-		if len(pathComponents) < 3 {
+		nodeID, _, ok := ParseNodeLink(nodeHref)
+		if !ok {
 			continue
 		}
 
@@ -290,7 +281,7 @@ func (l *LotsClient) extractLots(doc *goquery.Document) (map[NodeID][]OfferID, e
 			offerIDs = append(offerIDs, OfferID(offerID))
 		})
 
-		lots[NodeID(pathComponents[2])] = offerIDs
+		lots[nodeID] = offerIDs
 	}
 
 	return lots, nil
@@ -311,6 +302,8 @@ func (l *LotsClient) Update(ctx context.Context) error {
 
 	l.updateList(lots)
 
+	l.logger().LogAttrs(ctx, slog.LevelDebug, "lots updated", slog.Int("nodes", len(lots)))
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package lots_test
 
 import (
 	"errors"
+	"log/slog"
 	"net/url"
 	"reflect"
 	"strings"
@@ -14,6 +15,15 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// newFunpay creates mock with no-op logger and tracer. Other calls must be expected by the test.
+func newFunpay(ctrl *gomock.Controller) *mocks.MockFunpay {
+	fp := mocks.NewMockFunpay(ctrl)
+	fp.EXPECT().Logger().AnyTimes().Return(slog.New(slog.DiscardHandler))
+	fp.EXPECT().Tracer().AnyTimes().Return(funpay.NoopTracer{})
+
+	return fp
+}
+
 func TestLots_Save(t *testing.T) {
 	t.Parallel()
 	t.Run("successful request", func(t *testing.T) {
@@ -22,7 +32,7 @@ func TestLots_Save(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		saveLotFields := lots.Fields{
@@ -56,7 +66,7 @@ func TestLots_Save(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
@@ -83,7 +93,7 @@ func TestLots_FieldsByOfferID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
@@ -132,7 +142,7 @@ func TestLots_FieldsByOfferID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		fp.EXPECT().BaseURL().Times(1).Return(":not a url")
@@ -149,7 +159,7 @@ func TestLots_FieldsByOfferID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
@@ -170,7 +180,7 @@ func TestLots_FieldsByOfferID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
@@ -204,7 +214,7 @@ func TestLots_FieldsByOfferID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
@@ -243,7 +253,7 @@ func TestLots_FieldsByNodeID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
@@ -283,7 +293,7 @@ func TestLots_FieldsByNodeID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		fp.EXPECT().BaseURL().Times(1).Return(":not a url")
@@ -300,7 +310,7 @@ func TestLots_FieldsByNodeID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
@@ -321,7 +331,7 @@ func TestLots_FieldsByNodeID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
@@ -355,7 +365,7 @@ func TestLots_FieldsByNodeID(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
@@ -395,19 +405,65 @@ func TestLots_ByUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
 			<body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'>
 				<div class="offer">
-					<h3><a href="/games/game1/">Game 1</a></h3>
-					<a class="tc-item" href="/lots/lot1?id=1"></a>
-					<a class="tc-item" href="/lots/lot2?id=2"></a>
+					<h3><a href="https://funpay.com/lots/1000/">Game 1</a></h3>
+					<a class="tc-item" href="/lots/offer?id=1"></a>
+					<a class="tc-item" href="/lots/offer?id=2"></a>
+				</div>
+				<div class="offer">
+					<h3><a href="https://funpay.com/chips/2000/">Game 2</a></h3>
+					<a class="tc-item" href="/chips/offer?id=3"></a>
+				</div>
+			</body>
+		</html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(
+			t.Context(),
+			"https://funpay.com/users/123/",
+		).Times(1).Return(doc, nil)
+
+		userLots, err := fpLots.ByUser(t.Context(), 123)
+		if err != nil {
+			t.Fatalf("LotsByUser failed: %v", err)
+		}
+
+		expected := map[lots.NodeID][]lots.OfferID{
+			"1000": {"1", "2"},
+			"2000": {"3"},
+		}
+
+		if !reflect.DeepEqual(userLots, expected) {
+			t.Errorf("expected %v, got %v", expected, userLots)
+		}
+	})
+
+	t.Run("localized node links", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := newFunpay(ctrl)
+		fpLots := lots.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+			<body data-app-data='{"userId":123,"csrf-token":"test","locale":"en"}'>
+				<div class="offer">
+					<h3><a href="https://funpay.com/en/lots/2852/">Game 1</a></h3>
+					<a class="tc-item" href="/en/lots/offer?id=1"></a>
 				</div>
 				<div class="offer">
-					<h3><a href="/games/game2/">Game 2</a></h3>
-					<a class="tc-item" href="/lots/lot3?id=3"></a>
+					<h3><a href="/en/chips/125/">Game 2</a></h3>
+					<a class="tc-item" href="/en/chips/offer?id=2"></a>
 				</div>
 			</body>
 		</html>`))
@@ -427,8 +483,8 @@ func TestLots_ByUser(t *testing.T) {
 		}
 
 		expected := map[lots.NodeID][]lots.OfferID{
-			"game1": {"1", "2"},
-			"game2": {"3"},
+			"2852": {"1"},
+			"125":  {"2"},
 		}
 
 		if !reflect.DeepEqual(userLots, expected) {
@@ -442,7 +498,7 @@ func TestLots_ByUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		fp.EXPECT().BaseURL().Times(1).Return(":not a url")
@@ -459,7 +515,7 @@ func TestLots_ByUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
@@ -480,7 +536,7 @@ func TestLots_ByUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
@@ -512,7 +568,7 @@ func TestLots_ByUser(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
@@ -547,14 +603,14 @@ func TestLots_UpdateLots(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
 			<body data-app-data='{"userId":456,"csrf-token":"new-csrf","locale":"en"}'>
 				<div class="offer">
-					<h3><a href="/games/game1/">Game 1</a></h3>
-					<a class="tc-item" href="/lots/lot1?id=1"></a>
+					<h3><a href="/en/lots/1000/">Game 1</a></h3>
+					<a class="tc-item" href="/en/lots/offer?id=1"></a>
 				</div>
 			</body>
 		</html>`))
@@ -585,7 +641,7 @@ func TestLots_UpdateLots(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		fp := mocks.NewMockFunpay(ctrl)
+		fp := newFunpay(ctrl)
 		fpLots := lots.New(fp)
 
 		fp.EXPECT().UserID().Times(1).Return(int64(0))
@@ -0,0 +1,27 @@
+package lots
+
+import (
+	"fmt"
+
+	"github.com/kostromin59/funpay"
+)
+
+// FieldPrice is the key of the price field. Price is set in the account currency.
+const FieldPrice FieldKey = "price"
+
+// Price parses value of [FieldPrice]. Currency of result is empty, because the field contains number only.
+func (f Fields) Price() (funpay.Money, error) {
+	price, err := funpay.ParseMoney(f[FieldPrice].Value)
+	if err != nil {
+		return funpay.Money{}, fmt.Errorf("Fields.Price: %w", err)
+	}
+
+	return price, nil
+}
+
+// SetPrice replaces value of [FieldPrice]. Currency of price is ignored.
+func (f Fields) SetPrice(price funpay.Money) {
+	field := f[FieldPrice]
+	field.Value = price.Decimal()
+	f[FieldPrice] = field
+}
@@ -0,0 +1,33 @@
+package lots_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/lots"
+)
+
+func TestFields_Price(t *testing.T) {
+	t.Parallel()
+
+	fields := lots.Fields{lots.FieldPrice: {Value: "1 234,5"}}
+
+	price, err := fields.Price()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := funpay.NewMoney(1234, 50, ""); price != expected {
+		t.Fatalf("expected %v, got %v", expected, price)
+	}
+
+	fields.SetPrice(price.Mul(2))
+	if fields[lots.FieldPrice].Value != "2469.00" {
+		t.Fatalf("unexpected price value: %q", fields[lots.FieldPrice].Value)
+	}
+
+	if _, err := (lots.Fields{}).Price(); !errors.Is(err, funpay.ErrInvalidMoney) {
+		t.Fatalf("expected ErrInvalidMoney, got %v", err)
+	}
+}
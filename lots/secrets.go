@@ -0,0 +1,165 @@
+package lots
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+const (
+	// FieldOfferID is the key of the offer id field.
+	FieldOfferID FieldKey = "offer_id"
+
+	// FieldSecrets is the key of the textarea with auto-delivery goods (one item per line).
+	FieldSecrets FieldKey = "secrets"
+
+	// FieldNodeID is the key of the node (category) id field.
+	FieldNodeID FieldKey = "node_id"
+
+	// FieldAutoDelivery is the key of the checkbox enabling auto-delivery.
+	FieldAutoDelivery FieldKey = "auto_delivery"
+)
+
+// Secrets returns auto-delivery goods stored in [FieldSecrets]. Empty lines are skipped.
+func (f Fields) Secrets() []string {
+	return ParseSecrets(f[FieldSecrets].Value)
+}
+
+// SetSecrets replaces value of [FieldSecrets] with provided secrets and enables auto-delivery.
+func (f Fields) SetSecrets(secrets []string) {
+	f[FieldSecrets] = Field{Value: JoinSecrets(secrets)}
+
+	autoDelivery := f[FieldAutoDelivery]
+	autoDelivery.Value = "on"
+	f[FieldAutoDelivery] = autoDelivery
+}
+
+// AutoDelivery reports whether auto-delivery checkbox is enabled.
+func (f Fields) AutoDelivery() bool {
+	return f[FieldAutoDelivery].Value == "on"
+}
+
+// ParseSecrets splits secrets textarea value into items. Items are trimmed, empty lines are skipped.
+func ParseSecrets(value string) []string {
+	lines := strings.Split(value, "\n")
+	secrets := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		secrets = append(secrets, line)
+	}
+
+	return secrets
+}
+
+// JoinSecrets joins secrets into textarea value (one item per line).
+func JoinSecrets(secrets []string) string {
+	return strings.Join(secrets, "\n")
+}
+
+func (l *LotsClient) Secrets(ctx context.Context, offerID OfferID) ([]string, error) {
+	const op = "LotsClient.Secrets"
+
+	fields, err := l.FieldsByOfferID(ctx, offerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return fields.Secrets(), nil
+}
+
+func (l *LotsClient) AddSecrets(ctx context.Context, offerID OfferID, secrets ...string) error {
+	const op = "LotsClient.AddSecrets"
+
+	err := l.updateSecrets(ctx, offerID, func(current []string) []string {
+		return append(current, ParseSecrets(JoinSecrets(secrets))...)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (l *LotsClient) RemoveSecrets(ctx context.Context, offerID OfferID, secrets ...string) error {
+	const op = "LotsClient.RemoveSecrets"
+
+	err := l.updateSecrets(ctx, offerID, func(current []string) []string {
+		return slices.DeleteFunc(current, func(s string) bool {
+			return slices.Contains(secrets, s)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (l *LotsClient) ReplaceSecrets(ctx context.Context, offerID OfferID, secrets []string) error {
+	const op = "LotsClient.ReplaceSecrets"
+
+	err := l.updateSecrets(ctx, offerID, func([]string) []string {
+		return ParseSecrets(JoinSecrets(secrets))
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (l *LotsClient) LowStock(ctx context.Context, threshold int) (map[OfferID]int, error) {
+	const op = "LotsClient.LowStock"
+
+	lowStock := make(map[OfferID]int)
+	for _, offerIDs := range l.List() {
+		for _, offerID := range offerIDs {
+			fields, err := l.FieldsByOfferID(ctx, offerID)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			if !fields.AutoDelivery() {
+				continue
+			}
+
+			count := len(fields.Secrets())
+			if count < threshold {
+				lowStock[offerID] = count
+			}
+		}
+	}
+
+	return lowStock, nil
+}
+
+func (l *LotsClient) updateSecrets(ctx context.Context, offerID OfferID, update func(current []string) []string) error {
+	const op = "LotsClient.updateSecrets"
+
+	fields, err := l.FieldsByOfferID(ctx, offerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	before := len(fields.Secrets())
+	fields.SetSecrets(update(fields.Secrets()))
+
+	if err := l.Save(ctx, fields); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Secrets themselves are never logged.
+	l.logger().LogAttrs(ctx, slog.LevelInfo, "lot secrets updated",
+		slog.String("offer_id", string(offerID)),
+		slog.Int("before", before),
+		slog.Int("after", len(fields.Secrets())),
+	)
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package lots_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/lots"
+	"go.uber.org/mock/gomock"
+)
+
+func secretsDoc(t *testing.T, secrets string, autoDelivery bool) *goquery.Document {
+	t.Helper()
+
+	checked := ""
+	if autoDelivery {
+		checked = "checked"
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+		<body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'>
+			<form>
+				<input type="hidden" name="offer_id" value="1">
+				<input type="checkbox" name="auto_delivery" ` + checked + `>
+				<textarea name="secrets">` + secrets + `</textarea>
+			</form>
+		</body>
+	</html>`))
+	if err != nil {
+		t.Fatal("invalid doc provided")
+	}
+
+	return doc
+}
+
+func TestParseSecrets(t *testing.T) {
+	t.Parallel()
+
+	secrets := lots.ParseSecrets("first\r\n\n  second  \n\nthird\n")
+	expected := []string{"first", "second", "third"}
+
+	if !reflect.DeepEqual(secrets, expected) {
+		t.Errorf("expected %v, got %v", expected, secrets)
+	}
+}
+
+func TestLots_Secrets(t *testing.T) {
+	t.Parallel()
+	t.Run("successful secrets retrieval", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := newFunpay(ctrl)
+		fpLots := lots.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(
+			t.Context(),
+			"https://funpay.com/lots/offerEdit?offer=1",
+		).Times(1).Return(secretsDoc(t, "a\nb\n", true), nil)
+
+		secrets, err := fpLots.Secrets(t.Context(), "1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{"a", "b"}
+		if !reflect.DeepEqual(secrets, expected) {
+			t.Errorf("expected %v, got %v", expected, secrets)
+		}
+	})
+
+	t.Run("unauthorized request", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := newFunpay(ctrl)
+		fpLots := lots.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(
+			t.Context(),
+			"https://funpay.com/lots/offerEdit?offer=1",
+		).Times(1).Return(nil, funpay.ErrAccountUnauthorized)
+
+		_, err := fpLots.Secrets(t.Context(), "1")
+		if !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Fatalf("expected ErrAccountUnauthorized, got %v", err)
+		}
+	})
+}
+
+func TestFields_SetSecrets(t *testing.T) {
+	t.Parallel()
+
+	fields := lots.Fields{
+		lots.FieldAutoDelivery: lots.Field{Variants: []string{"on"}},
+	}
+	fields.SetSecrets([]string{"a", "b"})
+
+	if !fields.AutoDelivery() {
+		t.Error("expected auto-delivery to be enabled")
+	}
+
+	expected := lots.Field{Value: "on", Variants: []string{"on"}}
+	if !reflect.DeepEqual(fields[lots.FieldAutoDelivery], expected) {
+		t.Errorf("expected %+v, got %+v", expected, fields[lots.FieldAutoDelivery])
+	}
+
+	if fields[lots.FieldSecrets].Value != "a\nb" {
+		t.Errorf("expected %q, got %q", "a\nb", fields[lots.FieldSecrets].Value)
+	}
+}
+
+func TestLots_UpdateSecrets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		update func(l lots.Lots) error
+	}{
+		{
+			name: "add secrets",
+			update: func(l lots.Lots) error {
+				return l.AddSecrets(t.Context(), "1", "c", "d")
+			},
+		},
+		{
+			name: "remove secrets",
+			update: func(l lots.Lots) error {
+				return l.RemoveSecrets(t.Context(), "1", "a")
+			},
+		},
+		{
+			name: "replace secrets",
+			update: func(l lots.Lots) error {
+				return l.ReplaceSecrets(t.Context(), "1", []string{"x"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fp := newFunpay(ctrl)
+			fpLots := lots.New(fp)
+
+			fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+			fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+			fp.EXPECT().RequestHTML(
+				gomock.Any(),
+				"https://funpay.com/lots/offerEdit?offer=1",
+			).Times(1).Return(secretsDoc(t, "a\nb", false), nil)
+			fp.EXPECT().Request(
+				gomock.Any(),
+				"https://funpay.com/lots/offerSave",
+				gomock.Any(),
+			).Times(1).Return(nil, nil)
+
+			if err := tt.update(fpLots); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("save error handling", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := newFunpay(ctrl)
+		fpLots := lots.New(fp)
+
+		fp.EXPECT().BaseURL().Times(2).Return("https://funpay.com")
+		fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+		fp.EXPECT().RequestHTML(
+			gomock.Any(),
+			"https://funpay.com/lots/offerEdit?offer=1",
+		).Times(1).Return(secretsDoc(t, "a", true), nil)
+		fp.EXPECT().Request(
+			gomock.Any(),
+			"https://funpay.com/lots/offerSave",
+			gomock.Any(),
+		).Times(1).Return(nil, errors.New("request error"))
+
+		if err := fpLots.AddSecrets(t.Context(), "1", "b"); err == nil {
+			t.Error("expected error when save fails")
+		}
+	})
+}
+
+func TestLots_LowStock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fp := newFunpay(ctrl)
+	fpLots := lots.New(fp)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+		<body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'>
+			<div class="offer">
+				<h3><a href="/lots/10/">Game 1</a></h3>
+				<a class="tc-item" href="/lots/offer?id=1"></a>
+				<a class="tc-item" href="/lots/offer?id=2"></a>
+				<a class="tc-item" href="/lots/offer?id=3"></a>
+			</div>
+		</body>
+	</html>`))
+	if err != nil {
+		t.Fatal("invalid doc provided")
+	}
+
+	fp.EXPECT().UserID().Times(1).Return(int64(123))
+	fp.EXPECT().BaseURL().Times(4).Return("https://funpay.com")
+	fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/users/123/").Times(1).Return(doc, nil)
+	fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/lots/offerEdit?offer=1").
+		Times(1).Return(secretsDoc(t, "a", true), nil)
+	fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/lots/offerEdit?offer=2").
+		Times(1).Return(secretsDoc(t, "a\nb\nc", true), nil)
+	fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/lots/offerEdit?offer=3").
+		Times(1).Return(secretsDoc(t, "", false), nil)
+
+	if err := fpLots.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lowStock, err := fpLots.LowStock(t.Context(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[lots.OfferID]int{"1": 1}
+	if !reflect.DeepEqual(lowStock, expected) {
+		t.Errorf("expected %v, got %v", expected, lowStock)
+	}
+}
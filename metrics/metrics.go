@@ -0,0 +1,322 @@
+package metrics
+
+import (
+	"cmp"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kostromin59/funpay"
+)
+
+// DefaultBuckets are upper bounds of latency histogram in seconds.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// StatusError is status label of requests failed without response (connection errors, timeouts,
+// nil response returned by the next middleware).
+const StatusError = "error"
+
+type requestKey struct {
+	method string
+	path   string
+	status string
+}
+
+type pathKey struct {
+	method string
+	path   string
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Metrics collects request metrics of [funpay.Funpay] clients:
+//   - funpay_requests_total{method,path,status} - count of requests;
+//   - funpay_request_duration_seconds{method,path} - latency histogram;
+//   - funpay_rate_limited_total{method,path} - count of 429 responses;
+//   - funpay_forbidden_total{method,path} - count of 403 responses;
+//   - funpay_request_retries_total{method,path} - count of retries reported by [Metrics.ObserveRetry].
+//
+// Path is a template without query, locale prefix and IDs (e.g. /orders/{id}/), see [PathTemplate].
+type Metrics struct {
+	buckets []float64
+	path    func(u *url.URL) string
+
+	requests    map[requestKey]uint64
+	durations   map[pathKey]*histogram
+	rateLimited map[pathKey]uint64
+	forbidden   map[pathKey]uint64
+	retries     map[pathKey]uint64
+	mu          sync.Mutex
+}
+
+// Opt defines a function type for modifying [Metrics].
+type Opt func(m *Metrics)
+
+// WithBuckets sets upper bounds of latency histogram in seconds.
+// Default: [DefaultBuckets]
+func WithBuckets(buckets ...float64) Opt {
+	return func(m *Metrics) {
+		m.buckets = slices.Sorted(slices.Values(buckets))
+	}
+}
+
+// WithPathTemplate sets function which converts request URL into path label.
+// Default: [PathTemplate]
+func WithPathTemplate(path func(u *url.URL) string) Opt {
+	return func(m *Metrics) {
+		m.path = path
+	}
+}
+
+// New creates empty [Metrics]. Use [Metrics.Instrument] or [Metrics.Middleware] to collect metrics of the client.
+func New(opts ...Opt) *Metrics {
+	m := &Metrics{
+		buckets:     DefaultBuckets,
+		path:        PathTemplate,
+		requests:    make(map[requestKey]uint64),
+		durations:   make(map[pathKey]*histogram),
+		rateLimited: make(map[pathKey]uint64),
+		forbidden:   make(map[pathKey]uint64),
+		retries:     make(map[pathKey]uint64),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Instrument adds [Metrics.Middleware] to the client.
+func (m *Metrics) Instrument(fp funpay.Funpay) {
+	fp.Use(m.Middleware())
+}
+
+// Middleware returns [funpay.Middleware] which observes every request.
+func (m *Metrics) Middleware() funpay.Middleware {
+	return func(next funpay.Doer) funpay.Doer {
+		return funpay.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			status := StatusError
+			if err == nil && resp != nil {
+				status = strconv.Itoa(resp.StatusCode)
+			}
+
+			m.observe(req.Method, m.path(req.URL), status, time.Since(start))
+
+			return resp, err
+		})
+	}
+}
+
+// ObserveRetry counts retry of the request. Call it from your retry logic.
+func (m *Metrics) ObserveRetry(method string, u *url.URL) {
+	m.mu.Lock()
+	m.retries[pathKey{method: method, path: m.path(u)}]++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) observe(method, path, status string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{method: method, path: path, status: status}]++
+
+	key := pathKey{method: method, path: path}
+	h, ok := m.durations[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.durations[key] = h
+	}
+
+	seconds := duration.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+
+	switch status {
+	case strconv.Itoa(http.StatusTooManyRequests):
+		m.rateLimited[key]++
+	case strconv.Itoa(http.StatusForbidden):
+		m.forbidden[key]++
+	}
+}
+
+// WritePrometheus writes metrics in Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP funpay_requests_total Count of Funpay requests.\n")
+	b.WriteString("# TYPE funpay_requests_total counter\n")
+	for _, key := range sortedKeys(m.requests, compareRequestKeys) {
+		fmt.Fprintf(&b, "funpay_requests_total{method=%q,path=%q,status=%q} %d\n", key.method, key.path, key.status, m.requests[key])
+	}
+
+	b.WriteString("# HELP funpay_request_duration_seconds Latency of Funpay requests.\n")
+	b.WriteString("# TYPE funpay_request_duration_seconds histogram\n")
+	for _, key := range sortedKeys(m.durations, comparePathKeys) {
+		h := m.durations[key]
+		for i, bound := range m.buckets {
+			fmt.Fprintf(&b, "funpay_request_duration_seconds_bucket{method=%q,path=%q,le=%q} %d\n",
+				key.method, key.path, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(&b, "funpay_request_duration_seconds_bucket{method=%q,path=%q,le=\"+Inf\"} %d\n", key.method, key.path, h.count)
+		fmt.Fprintf(&b, "funpay_request_duration_seconds_sum{method=%q,path=%q} %s\n", key.method, key.path, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "funpay_request_duration_seconds_count{method=%q,path=%q} %d\n", key.method, key.path, h.count)
+	}
+
+	writeCounter(&b, "funpay_rate_limited_total", "Count of Funpay responses with status 429.", m.rateLimited)
+	writeCounter(&b, "funpay_forbidden_total", "Count of Funpay responses with status 403.", m.forbidden)
+	writeCounter(&b, "funpay_request_retries_total", "Count of retried Funpay requests.", m.retries)
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// Handler returns [http.Handler] which serves metrics for Prometheus scraping.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		m.WritePrometheus(w)
+	})
+}
+
+// Publish publishes metrics as expvar variable (served on /debug/vars by [expvar] handler).
+// Panics if the name is already registered, like [expvar.Publish].
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(m.snapshot))
+}
+
+// snapshot returns metrics as JSON compatible value for [expvar].
+func (m *Metrics) snapshot() any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type requests struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Status string `json:"status"`
+		Count  uint64 `json:"count"`
+	}
+
+	type path struct {
+		Method          string  `json:"method"`
+		Path            string  `json:"path"`
+		Count           uint64  `json:"count"`
+		DurationSeconds float64 `json:"duration_seconds"`
+		RateLimited     uint64  `json:"rate_limited"`
+		Forbidden       uint64  `json:"forbidden"`
+		Retries         uint64  `json:"retries"`
+	}
+
+	snapshot := struct {
+		Requests []requests `json:"requests"`
+		Paths    []path     `json:"paths"`
+	}{}
+
+	for _, key := range sortedKeys(m.requests, compareRequestKeys) {
+		snapshot.Requests = append(snapshot.Requests, requests{Method: key.method, Path: key.path, Status: key.status, Count: m.requests[key]})
+	}
+
+	for _, key := range sortedKeys(m.durations, comparePathKeys) {
+		h := m.durations[key]
+		snapshot.Paths = append(snapshot.Paths, path{
+			Method:          key.method,
+			Path:            key.path,
+			Count:           h.count,
+			DurationSeconds: h.sum,
+			RateLimited:     m.rateLimited[key],
+			Forbidden:       m.forbidden[key],
+			Retries:         m.retries[key],
+		})
+	}
+
+	return snapshot
+}
+
+// PathTemplate returns URL path without locale prefix, replacing numeric segments and order IDs with {id}:
+// /en/users/123/ becomes /users/{id}/, /orders/ABCD1234/ becomes /orders/{id}/.
+func PathTemplate(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	segments := strings.Split(u.Path, "/")
+	if len(segments) > 1 {
+		if _, ok := funpay.LocaleByPrefix(segments[1]); ok {
+			segments = slices.Delete(segments, 1, 2)
+		}
+	}
+
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+
+		if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+			segments[i] = "{id}"
+			continue
+		}
+
+		if i > 0 && segments[i-1] == "orders" && segment != "trade" && segment != "review" {
+			segments[i] = "{id}"
+		}
+	}
+
+	path := strings.Join(segments, "/")
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
+
+func writeCounter(b *strings.Builder, name, help string, values map[pathKey]uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s counter\n", name)
+	for _, key := range sortedKeys(values, comparePathKeys) {
+		fmt.Fprintf(b, "%s{method=%q,path=%q} %d\n", name, key.method, key.path, values[key])
+	}
+}
+
+func sortedKeys[K comparable, V any](m map[K]V, compare func(a, b K) int) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, compare)
+
+	return keys
+}
+
+func comparePathKeys(a, b pathKey) int {
+	return cmp.Or(strings.Compare(a.path, b.path), strings.Compare(a.method, b.method))
+}
+
+func compareRequestKeys(a, b requestKey) int {
+	return cmp.Or(
+		comparePathKeys(pathKey{method: a.method, path: a.path}, pathKey{method: b.method, path: b.path}),
+		strings.Compare(a.status, b.status),
+	)
+}
@@ -0,0 +1,142 @@
+package metrics_test
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/metrics"
+)
+
+func TestPathTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"https://funpay.com":                         "/",
+		"https://funpay.com/users/123/":              "/users/{id}/",
+		"https://funpay.com/en/users/123/":           "/users/{id}/",
+		"https://funpay.com/orders/ABCD1234/":        "/orders/{id}/",
+		"https://funpay.com/orders/trade":            "/orders/trade",
+		"https://funpay.com/lots/offerEdit?offer=10": "/lots/offerEdit",
+		"https://funpay.com/chips/2852/":             "/chips/{id}/",
+	}
+
+	for raw, expected := range tests {
+		u, _ := url.Parse(raw)
+		if path := metrics.PathTemplate(u); path != expected {
+			t.Errorf("%s: expected %q, got %q", raw, expected, path)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/1/":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/users/2/":
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer ts.Close()
+
+	m := metrics.New(metrics.WithBuckets(10, 1))
+	fp := funpay.New("test_key", "test_agent")
+	m.Instrument(fp)
+
+	for _, path := range []string{"/users/1/", "/users/2/", "/orders/A/", "/orders/B/"} {
+		resp, _ := fp.Request(t.Context(), ts.URL+path)
+		resp.Body.Close()
+	}
+
+	if _, err := fp.Request(t.Context(), "http://127.0.0.1:0/lots/"); err == nil {
+		t.Fatal("expected connection error")
+	}
+
+	retryURL, _ := url.Parse(ts.URL + "/users/1/")
+	m.ObserveRetry(http.MethodGet, retryURL)
+
+	var b strings.Builder
+	if err := m.WritePrometheus(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`funpay_requests_total{method="GET",path="/lots/",status="error"} 1`,
+		`funpay_requests_total{method="GET",path="/orders/{id}/",status="200"} 2`,
+		`funpay_requests_total{method="GET",path="/users/{id}/",status="403"} 1`,
+		`funpay_requests_total{method="GET",path="/users/{id}/",status="429"} 1`,
+		`funpay_request_duration_seconds_bucket{method="GET",path="/orders/{id}/",le="1"} 2`,
+		`funpay_request_duration_seconds_bucket{method="GET",path="/orders/{id}/",le="10"} 2`,
+		`funpay_request_duration_seconds_bucket{method="GET",path="/orders/{id}/",le="+Inf"} 2`,
+		`funpay_request_duration_seconds_count{method="GET",path="/users/{id}/"} 2`,
+		`funpay_rate_limited_total{method="GET",path="/users/{id}/"} 1`,
+		`funpay_forbidden_total{method="GET",path="/users/{id}/"} 1`,
+		`funpay_request_retries_total{method="GET",path="/users/{id}/"} 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("expected line %q in:\n%s", line, b.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if string(body) != b.String() || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("unexpected handler response: %s", body)
+	}
+
+	m.Publish("funpay_metrics_test")
+
+	var snapshot struct {
+		Paths []struct {
+			Path        string `json:"path"`
+			Count       uint64 `json:"count"`
+			RateLimited uint64 `json:"rate_limited"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("funpay_metrics_test").String()), &snapshot); err != nil {
+		t.Fatalf("invalid expvar value: %v", err)
+	}
+
+	if len(snapshot.Paths) != 3 || snapshot.Paths[2].Path != "/users/{id}/" || snapshot.Paths[2].RateLimited != 1 {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+}
+
+func TestMetrics_NilResponse(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.New()
+	fp := funpay.New("test_key", "test_agent")
+	m.Instrument(fp)
+	fp.Use(func(next funpay.Doer) funpay.Doer {
+		return funpay.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, nil
+		})
+	})
+
+	if _, err := fp.Request(t.Context(), "http://funpay.test/lots/"); !errors.Is(err, funpay.ErrNilResponse) {
+		t.Fatalf("expected ErrNilResponse, got %v", err)
+	}
+
+	var b strings.Builder
+	if err := m.WritePrometheus(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `funpay_requests_total{method="GET",path="/lots/",status="error"} 1`
+	if !strings.Contains(b.String(), expected) {
+		t.Errorf("expected %q in output:\n%s", expected, b.String())
+	}
+}
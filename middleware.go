@@ -0,0 +1,39 @@
+package funpay
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrNilResponse indicates that middleware returned neither response nor error.
+	ErrNilResponse = errors.New("nil response")
+)
+
+// Doer executes prepared HTTP request. [*http.Client] implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to use ordinary functions as [Doer].
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps [Doer] to add behaviour around requests (see [FunpayRequester.Use]).
+//
+// Middleware receives request with cookies, golden key and User-Agent already set and returns
+// response before status code validation. It may modify request, replace response or skip calling next.
+// Response without body gets [http.NoBody], nil response without error is reported as [ErrNilResponse].
+type Middleware func(next Doer) Doer
+
+// chain wraps doer with middlewares. The first middleware is the outermost.
+func chain(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+
+	return doer
+}
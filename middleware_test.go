@@ -0,0 +1,146 @@
+package funpay_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+func TestFunpay_Use(t *testing.T) {
+	t.Parallel()
+	t.Run("middlewares are called in order", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, r.Header.Get("X-Trace"))
+		}))
+		defer ts.Close()
+
+		var calls []string
+		trace := func(name string) funpay.Middleware {
+			return func(next funpay.Doer) funpay.Doer {
+				return funpay.DoerFunc(func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, name)
+					req.Header.Set("X-Trace", req.Header.Get("X-Trace")+name)
+
+					if req.Header.Get(funpay.HeaderUserAgent) != "test_agent" {
+						t.Errorf("expected prepared request, got user agent %q", req.Header.Get(funpay.HeaderUserAgent))
+					}
+
+					resp, err := next.Do(req)
+					calls = append(calls, name+" done")
+
+					return resp, err
+				})
+			}
+		}
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.Use(trace("a"), trace("b"))
+		fp.Use(trace("c"))
+
+		resp, err := fp.Request(t.Context(), ts.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "abc" {
+			t.Errorf("expected header abc, got %q", body)
+		}
+
+		expected := "a b c c done b done a done"
+		if strings.Join(calls, " ") != expected {
+			t.Errorf("expected calls %q, got %q", expected, strings.Join(calls, " "))
+		}
+	})
+
+	t.Run("middleware replaces response", func(t *testing.T) {
+		t.Parallel()
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.Use(func(next funpay.Doer) funpay.Doer {
+			return funpay.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("")),
+					Request:    req,
+				}, nil
+			})
+		})
+
+		resp, err := fp.Request(t.Context(), "http://funpay.test/")
+		if !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Fatalf("expected ErrAccountUnauthorized, got %v", err)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("middleware error", func(t *testing.T) {
+		t.Parallel()
+
+		errCached := errors.New("cached")
+		fp := funpay.New("test_key", "test_agent")
+		fp.Use(func(next funpay.Doer) funpay.Doer {
+			return funpay.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, errCached
+			})
+		})
+
+		if _, err := fp.Request(t.Context(), "http://funpay.test/"); !errors.Is(err, errCached) {
+			t.Errorf("expected middleware error, got %v", err)
+		}
+	})
+
+	t.Run("middleware returns nil response", func(t *testing.T) {
+		t.Parallel()
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.Use(func(next funpay.Doer) funpay.Doer {
+			return funpay.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, nil
+			})
+		})
+
+		resp, err := fp.Request(t.Context(), "http://funpay.test/")
+		if !errors.Is(err, funpay.ErrNilResponse) {
+			t.Fatalf("expected ErrNilResponse, got %v", err)
+		}
+
+		if resp != nil {
+			t.Errorf("expected nil response, got %v", resp)
+		}
+	})
+
+	t.Run("middleware returns response without body", func(t *testing.T) {
+		t.Parallel()
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.Use(func(next funpay.Doer) funpay.Doer {
+			return funpay.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+			})
+		})
+
+		resp, err := fp.Request(t.Context(), "http://funpay.test/")
+		if !errors.Is(err, funpay.ErrBadStatusCode) {
+			t.Fatalf("expected ErrBadStatusCode, got %v", err)
+		}
+
+		if resp.Body != http.NoBody {
+			t.Errorf("expected http.NoBody, got %v", resp.Body)
+		}
+		resp.Body.Close()
+
+		if _, err := fp.RequestHTML(t.Context(), "http://funpay.test/"); !errors.Is(err, funpay.ErrBadStatusCode) {
+			t.Errorf("expected ErrBadStatusCode, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kostromin59/funpay/catalog (interfaces: Catalog)
+//
+// Generated by this command:
+//
+//	mockgen -destination ../mocks/catalog.go -package mocks . Catalog
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	funpay "github.com/kostromin59/funpay"
+	catalog "github.com/kostromin59/funpay/catalog"
+	lots "github.com/kostromin59/funpay/lots"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalog is a mock of Catalog interface.
+type MockCatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogMockRecorder is the mock recorder for MockCatalog.
+type MockCatalogMockRecorder struct {
+	mock *MockCatalog
+}
+
+// NewMockCatalog creates a new mock instance.
+func NewMockCatalog(ctrl *gomock.Controller) *MockCatalog {
+	mock := &MockCatalog{ctrl: ctrl}
+	mock.recorder = &MockCatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalog) EXPECT() *MockCatalogMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockCatalog) Find(query string, locale funpay.Locale) []catalog.Match {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", query, locale)
+	ret0, _ := ret[0].([]catalog.Match)
+	return ret0
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockCatalogMockRecorder) Find(query, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCatalog)(nil).Find), query, locale)
+}
+
+// Game mocks base method.
+func (m *MockCatalog) Game(gameID catalog.GameID) (catalog.Game, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Game", gameID)
+	ret0, _ := ret[0].(catalog.Game)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Game indicates an expected call of Game.
+func (mr *MockCatalogMockRecorder) Game(gameID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Game", reflect.TypeOf((*MockCatalog)(nil).Game), gameID)
+}
+
+// Games mocks base method.
+func (m *MockCatalog) Games() []catalog.Game {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Games")
+	ret0, _ := ret[0].([]catalog.Game)
+	return ret0
+}
+
+// Games indicates an expected call of Games.
+func (mr *MockCatalogMockRecorder) Games() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Games", reflect.TypeOf((*MockCatalog)(nil).Games))
+}
+
+// Node mocks base method.
+func (m *MockCatalog) Node(nodeID lots.NodeID) (catalog.Node, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Node", nodeID)
+	ret0, _ := ret[0].(catalog.Node)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Node indicates an expected call of Node.
+func (mr *MockCatalogMockRecorder) Node(nodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Node", reflect.TypeOf((*MockCatalog)(nil).Node), nodeID)
+}
+
+// Schema mocks base method.
+func (m *MockCatalog) Schema(ctx context.Context, nodeID lots.NodeID) (lots.Fields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schema", ctx, nodeID)
+	ret0, _ := ret[0].(lots.Fields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schema indicates an expected call of Schema.
+func (mr *MockCatalogMockRecorder) Schema(ctx, nodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schema", reflect.TypeOf((*MockCatalog)(nil).Schema), ctx, nodeID)
+}
+
+// Update mocks base method.
+func (m *MockCatalog) Update(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCatalogMockRecorder) Update(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCatalog)(nil).Update), ctx)
+}
+
+// UpdateGame mocks base method.
+func (m *MockCatalog) UpdateGame(ctx context.Context, gameID catalog.GameID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGame", ctx, gameID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGame indicates an expected call of UpdateGame.
+func (mr *MockCatalogMockRecorder) UpdateGame(ctx, gameID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGame", reflect.TypeOf((*MockCatalog)(nil).UpdateGame), ctx, gameID)
+}
+
+// UpdateLocale mocks base method.
+func (m *MockCatalog) UpdateLocale(ctx context.Context, locale funpay.Locale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocale", ctx, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLocale indicates an expected call of UpdateLocale.
+func (mr *MockCatalogMockRecorder) UpdateLocale(ctx, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocale", reflect.TypeOf((*MockCatalog)(nil).UpdateLocale), ctx, locale)
+}
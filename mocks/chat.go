@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kostromin59/funpay/chat (interfaces: Chat)
+//
+// Generated by this command:
+//
+//	mockgen -destination ../mocks/chat.go -package mocks . Chat
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	chat "github.com/kostromin59/funpay/chat"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChat is a mock of Chat interface.
+type MockChat struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatMockRecorder
+	isgomock struct{}
+}
+
+// MockChatMockRecorder is the mock recorder for MockChat.
+type MockChatMockRecorder struct {
+	mock *MockChat
+}
+
+// NewMockChat creates a new mock instance.
+func NewMockChat(ctrl *gomock.Controller) *MockChat {
+	mock := &MockChat{ctrl: ctrl}
+	mock.recorder = &MockChatMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChat) EXPECT() *MockChatMockRecorder {
+	return m.recorder
+}
+
+// Chats mocks base method.
+func (m *MockChat) Chats(ctx context.Context) ([]chat.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Chats", ctx)
+	ret0, _ := ret[0].([]chat.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Chats indicates an expected call of Chats.
+func (mr *MockChatMockRecorder) Chats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chats", reflect.TypeOf((*MockChat)(nil).Chats), ctx)
+}
+
+// History mocks base method.
+func (m *MockChat) History(ctx context.Context, chatID chat.ChatID) ([]chat.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, chatID)
+	ret0, _ := ret[0].([]chat.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockChatMockRecorder) History(ctx, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockChat)(nil).History), ctx, chatID)
+}
+
+// Send mocks base method.
+func (m *MockChat) Send(ctx context.Context, chatID chat.ChatID, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, chatID, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockChatMockRecorder) Send(ctx, chatID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockChat)(nil).Send), ctx, chatID, text)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kostromin59/funpay/finance (interfaces: Finance)
+//
+// Generated by this command:
+//
+//	mockgen -destination ../mocks/finance.go -package mocks . Finance
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	funpay "github.com/kostromin59/funpay"
+	finance "github.com/kostromin59/funpay/finance"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFinance is a mock of Finance interface.
+type MockFinance struct {
+	ctrl     *gomock.Controller
+	recorder *MockFinanceMockRecorder
+	isgomock struct{}
+}
+
+// MockFinanceMockRecorder is the mock recorder for MockFinance.
+type MockFinanceMockRecorder struct {
+	mock *MockFinance
+}
+
+// NewMockFinance creates a new mock instance.
+func NewMockFinance(ctrl *gomock.Controller) *MockFinance {
+	mock := &MockFinance{ctrl: ctrl}
+	mock.recorder = &MockFinanceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFinance) EXPECT() *MockFinanceMockRecorder {
+	return m.recorder
+}
+
+// Balances mocks base method.
+func (m *MockFinance) Balances(ctx context.Context) ([]funpay.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balances", ctx)
+	ret0, _ := ret[0].([]funpay.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balances indicates an expected call of Balances.
+func (mr *MockFinanceMockRecorder) Balances(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balances", reflect.TypeOf((*MockFinance)(nil).Balances), ctx)
+}
+
+// PendingWithdrawals mocks base method.
+func (m *MockFinance) PendingWithdrawals(ctx context.Context) ([]finance.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingWithdrawals", ctx)
+	ret0, _ := ret[0].([]finance.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingWithdrawals indicates an expected call of PendingWithdrawals.
+func (mr *MockFinanceMockRecorder) PendingWithdrawals(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingWithdrawals", reflect.TypeOf((*MockFinance)(nil).PendingWithdrawals), ctx)
+}
+
+// PreviewWithdrawal mocks base method.
+func (m *MockFinance) PreviewWithdrawal(ctx context.Context, currency funpay.Currency, method finance.WithdrawalMethod, amount int64) (finance.WithdrawalPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewWithdrawal", ctx, currency, method, amount)
+	ret0, _ := ret[0].(finance.WithdrawalPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewWithdrawal indicates an expected call of PreviewWithdrawal.
+func (mr *MockFinanceMockRecorder) PreviewWithdrawal(ctx, currency, method, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewWithdrawal", reflect.TypeOf((*MockFinance)(nil).PreviewWithdrawal), ctx, currency, method, amount)
+}
+
+// Transactions mocks base method.
+func (m *MockFinance) Transactions(ctx context.Context, cursor string) (finance.TransactionsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transactions", ctx, cursor)
+	ret0, _ := ret[0].(finance.TransactionsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transactions indicates an expected call of Transactions.
+func (mr *MockFinanceMockRecorder) Transactions(ctx, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transactions", reflect.TypeOf((*MockFinance)(nil).Transactions), ctx, cursor)
+}
+
+// Withdraw mocks base method.
+func (m *MockFinance) Withdraw(ctx context.Context, currency funpay.Currency, method finance.WithdrawalMethod, wallet string, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, currency, method, wallet, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockFinanceMockRecorder) Withdraw(ctx, currency, method, wallet, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockFinance)(nil).Withdraw), ctx, currency, method, wallet, amount)
+}
@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	slog "log/slog"
 	http "net/http"
 	url "net/url"
 	reflect "reflect"
@@ -44,11 +45,25 @@ func (m *MockFunpay) EXPECT() *MockFunpayMockRecorder {
 	return m.recorder
 }
 
+// AppData mocks base method.
+func (m *MockFunpay) AppData() funpay.AppData {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppData")
+	ret0, _ := ret[0].(funpay.AppData)
+	return ret0
+}
+
+// AppData indicates an expected call of AppData.
+func (mr *MockFunpayMockRecorder) AppData() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppData", reflect.TypeOf((*MockFunpay)(nil).AppData))
+}
+
 // Balance mocks base method.
-func (m *MockFunpay) Balance() int64 {
+func (m *MockFunpay) Balance() funpay.Money {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Balance")
-	ret0, _ := ret[0].(int64)
+	ret0, _ := ret[0].(funpay.Money)
 	return ret0
 }
 
@@ -114,6 +129,20 @@ func (mr *MockFunpayMockRecorder) GoldenKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoldenKey", reflect.TypeOf((*MockFunpay)(nil).GoldenKey))
 }
 
+// Info mocks base method.
+func (m *MockFunpay) Info() funpay.UserInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info")
+	ret0, _ := ret[0].(funpay.UserInfo)
+	return ret0
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockFunpayMockRecorder) Info() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockFunpay)(nil).Info))
+}
+
 // Locale mocks base method.
 func (m *MockFunpay) Locale() funpay.Locale {
 	m.ctrl.T.Helper()
@@ -128,6 +157,34 @@ func (mr *MockFunpayMockRecorder) Locale() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Locale", reflect.TypeOf((*MockFunpay)(nil).Locale))
 }
 
+// Logger mocks base method.
+func (m *MockFunpay) Logger() *slog.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logger")
+	ret0, _ := ret[0].(*slog.Logger)
+	return ret0
+}
+
+// Logger indicates an expected call of Logger.
+func (mr *MockFunpayMockRecorder) Logger() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logger", reflect.TypeOf((*MockFunpay)(nil).Logger))
+}
+
+// RefreshProfile mocks base method.
+func (m *MockFunpay) RefreshProfile(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshProfile", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshProfile indicates an expected call of RefreshProfile.
+func (mr *MockFunpayMockRecorder) RefreshProfile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshProfile", reflect.TypeOf((*MockFunpay)(nil).RefreshProfile), ctx)
+}
+
 // Request mocks base method.
 func (m *MockFunpay) Request(ctx context.Context, requestURL string, opts ...funpay.RequestOpt) (*http.Response, error) {
 	m.ctrl.T.Helper()
@@ -180,6 +237,18 @@ func (mr *MockFunpayMockRecorder) SetBaseURL(baseURL any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBaseURL", reflect.TypeOf((*MockFunpay)(nil).SetBaseURL), baseURL)
 }
 
+// SetLogger mocks base method.
+func (m *MockFunpay) SetLogger(logger *slog.Logger) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLogger", logger)
+}
+
+// SetLogger indicates an expected call of SetLogger.
+func (mr *MockFunpayMockRecorder) SetLogger(logger any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogger", reflect.TypeOf((*MockFunpay)(nil).SetLogger), logger)
+}
+
 // SetProxy mocks base method.
 func (m *MockFunpay) SetProxy(proxy *url.URL) {
 	m.ctrl.T.Helper()
@@ -192,6 +261,58 @@ func (mr *MockFunpayMockRecorder) SetProxy(proxy any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProxy", reflect.TypeOf((*MockFunpay)(nil).SetProxy), proxy)
 }
 
+// SetProxySelector mocks base method.
+func (m *MockFunpay) SetProxySelector(selector funpay.ProxySelector) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetProxySelector", selector)
+}
+
+// SetProxySelector indicates an expected call of SetProxySelector.
+func (mr *MockFunpayMockRecorder) SetProxySelector(selector any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProxySelector", reflect.TypeOf((*MockFunpay)(nil).SetProxySelector), selector)
+}
+
+// SetTracer mocks base method.
+func (m *MockFunpay) SetTracer(tracer funpay.Tracer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTracer", tracer)
+}
+
+// SetTracer indicates an expected call of SetTracer.
+func (mr *MockFunpayMockRecorder) SetTracer(tracer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTracer", reflect.TypeOf((*MockFunpay)(nil).SetTracer), tracer)
+}
+
+// Status mocks base method.
+func (m *MockFunpay) Status() funpay.Status {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(funpay.Status)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockFunpayMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockFunpay)(nil).Status))
+}
+
+// Tracer mocks base method.
+func (m *MockFunpay) Tracer() funpay.Tracer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tracer")
+	ret0, _ := ret[0].(funpay.Tracer)
+	return ret0
+}
+
+// Tracer indicates an expected call of Tracer.
+func (mr *MockFunpayMockRecorder) Tracer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tracer", reflect.TypeOf((*MockFunpay)(nil).Tracer))
+}
+
 // Update mocks base method.
 func (m *MockFunpay) Update(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -206,6 +327,20 @@ func (mr *MockFunpayMockRecorder) Update(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFunpay)(nil).Update), ctx)
 }
 
+// UpdateCurrency mocks base method.
+func (m *MockFunpay) UpdateCurrency(ctx context.Context, currency funpay.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrency", ctx, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCurrency indicates an expected call of UpdateCurrency.
+func (mr *MockFunpayMockRecorder) UpdateCurrency(ctx, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrency", reflect.TypeOf((*MockFunpay)(nil).UpdateCurrency), ctx, currency)
+}
+
 // UpdateLocale mocks base method.
 func (m *MockFunpay) UpdateLocale(ctx context.Context, locale funpay.Locale) error {
 	m.ctrl.T.Helper()
@@ -220,6 +355,22 @@ func (mr *MockFunpayMockRecorder) UpdateLocale(ctx, locale any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocale", reflect.TypeOf((*MockFunpay)(nil).UpdateLocale), ctx, locale)
 }
 
+// Use mocks base method.
+func (m *MockFunpay) Use(middlewares ...funpay.Middleware) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range middlewares {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Use", varargs...)
+}
+
+// Use indicates an expected call of Use.
+func (mr *MockFunpayMockRecorder) Use(middlewares ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockFunpay)(nil).Use), middlewares...)
+}
+
 // UserAgent mocks base method.
 func (m *MockFunpay) UserAgent() string {
 	m.ctrl.T.Helper()
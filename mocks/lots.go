@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	funpay "github.com/kostromin59/funpay"
 	lots "github.com/kostromin59/funpay/lots"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,11 +42,30 @@ func (m *MockLots) EXPECT() *MockLotsMockRecorder {
 	return m.recorder
 }
 
+// AddSecrets mocks base method.
+func (m *MockLots) AddSecrets(ctx context.Context, offerID lots.OfferID, secrets ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, offerID}
+	for _, a := range secrets {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddSecrets", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSecrets indicates an expected call of AddSecrets.
+func (mr *MockLotsMockRecorder) AddSecrets(ctx, offerID any, secrets ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, offerID}, secrets...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecrets", reflect.TypeOf((*MockLots)(nil).AddSecrets), varargs...)
+}
+
 // ByUser mocks base method.
-func (m *MockLots) ByUser(ctx context.Context, userID int64) (map[string][]string, error) {
+func (m *MockLots) ByUser(ctx context.Context, userID int64) (map[lots.NodeID][]lots.OfferID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ByUser", ctx, userID)
-	ret0, _ := ret[0].(map[string][]string)
+	ret0, _ := ret[0].(map[lots.NodeID][]lots.OfferID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -56,26 +76,51 @@ func (mr *MockLotsMockRecorder) ByUser(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByUser", reflect.TypeOf((*MockLots)(nil).ByUser), ctx, userID)
 }
 
-// Fields mocks base method.
-func (m *MockLots) Fields(ctx context.Context, nodeID, offerID string) (lots.Fields, error) {
+// FieldsByNodeID mocks base method.
+func (m *MockLots) FieldsByNodeID(ctx context.Context, nodeID lots.NodeID, opts ...funpay.RequestOpt) (lots.Fields, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Fields", ctx, nodeID, offerID)
+	varargs := []any{ctx, nodeID}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FieldsByNodeID", varargs...)
 	ret0, _ := ret[0].(lots.Fields)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Fields indicates an expected call of Fields.
-func (mr *MockLotsMockRecorder) Fields(ctx, nodeID, offerID any) *gomock.Call {
+// FieldsByNodeID indicates an expected call of FieldsByNodeID.
+func (mr *MockLotsMockRecorder) FieldsByNodeID(ctx, nodeID any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fields", reflect.TypeOf((*MockLots)(nil).Fields), ctx, nodeID, offerID)
+	varargs := append([]any{ctx, nodeID}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FieldsByNodeID", reflect.TypeOf((*MockLots)(nil).FieldsByNodeID), varargs...)
+}
+
+// FieldsByOfferID mocks base method.
+func (m *MockLots) FieldsByOfferID(ctx context.Context, offerID lots.OfferID, opts ...funpay.RequestOpt) (lots.Fields, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, offerID}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FieldsByOfferID", varargs...)
+	ret0, _ := ret[0].(lots.Fields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FieldsByOfferID indicates an expected call of FieldsByOfferID.
+func (mr *MockLotsMockRecorder) FieldsByOfferID(ctx, offerID any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, offerID}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FieldsByOfferID", reflect.TypeOf((*MockLots)(nil).FieldsByOfferID), varargs...)
 }
 
 // List mocks base method.
-func (m *MockLots) List() map[string][]string {
+func (m *MockLots) List() map[lots.NodeID][]lots.OfferID {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List")
-	ret0, _ := ret[0].(map[string][]string)
+	ret0, _ := ret[0].(map[lots.NodeID][]lots.OfferID)
 	return ret0
 }
 
@@ -85,6 +130,54 @@ func (mr *MockLotsMockRecorder) List() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLots)(nil).List))
 }
 
+// LowStock mocks base method.
+func (m *MockLots) LowStock(ctx context.Context, threshold int) (map[lots.OfferID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LowStock", ctx, threshold)
+	ret0, _ := ret[0].(map[lots.OfferID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LowStock indicates an expected call of LowStock.
+func (mr *MockLotsMockRecorder) LowStock(ctx, threshold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LowStock", reflect.TypeOf((*MockLots)(nil).LowStock), ctx, threshold)
+}
+
+// RemoveSecrets mocks base method.
+func (m *MockLots) RemoveSecrets(ctx context.Context, offerID lots.OfferID, secrets ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, offerID}
+	for _, a := range secrets {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveSecrets", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSecrets indicates an expected call of RemoveSecrets.
+func (mr *MockLotsMockRecorder) RemoveSecrets(ctx, offerID any, secrets ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, offerID}, secrets...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSecrets", reflect.TypeOf((*MockLots)(nil).RemoveSecrets), varargs...)
+}
+
+// ReplaceSecrets mocks base method.
+func (m *MockLots) ReplaceSecrets(ctx context.Context, offerID lots.OfferID, secrets []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceSecrets", ctx, offerID, secrets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceSecrets indicates an expected call of ReplaceSecrets.
+func (mr *MockLotsMockRecorder) ReplaceSecrets(ctx, offerID, secrets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceSecrets", reflect.TypeOf((*MockLots)(nil).ReplaceSecrets), ctx, offerID, secrets)
+}
+
 // Save mocks base method.
 func (m *MockLots) Save(ctx context.Context, fields lots.Fields) error {
 	m.ctrl.T.Helper()
@@ -99,6 +192,21 @@ func (mr *MockLotsMockRecorder) Save(ctx, fields any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockLots)(nil).Save), ctx, fields)
 }
 
+// Secrets mocks base method.
+func (m *MockLots) Secrets(ctx context.Context, offerID lots.OfferID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Secrets", ctx, offerID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Secrets indicates an expected call of Secrets.
+func (mr *MockLotsMockRecorder) Secrets(ctx, offerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Secrets", reflect.TypeOf((*MockLots)(nil).Secrets), ctx, offerID)
+}
+
 // Update mocks base method.
 func (m *MockLots) Update(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kostromin59/funpay/orders (interfaces: Orders)
+//
+// Generated by this command:
+//
+//	mockgen -destination ../mocks/orders.go -package mocks . Orders
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	orders "github.com/kostromin59/funpay/orders"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrders is a mock of Orders interface.
+type MockOrders struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrdersMockRecorder
+	isgomock struct{}
+}
+
+// MockOrdersMockRecorder is the mock recorder for MockOrders.
+type MockOrdersMockRecorder struct {
+	mock *MockOrders
+}
+
+// NewMockOrders creates a new mock instance.
+func NewMockOrders(ctrl *gomock.Controller) *MockOrders {
+	mock := &MockOrders{ctrl: ctrl}
+	mock.recorder = &MockOrdersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrders) EXPECT() *MockOrdersMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockOrders) Get(ctx context.Context, orderID orders.OrderID) (orders.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, orderID)
+	ret0, _ := ret[0].(orders.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrdersMockRecorder) Get(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrders)(nil).Get), ctx, orderID)
+}
+
+// ReplyReview mocks base method.
+func (m *MockOrders) ReplyReview(ctx context.Context, orderID orders.OrderID, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyReview", ctx, orderID, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplyReview indicates an expected call of ReplyReview.
+func (mr *MockOrdersMockRecorder) ReplyReview(ctx, orderID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyReview", reflect.TypeOf((*MockOrders)(nil).ReplyReview), ctx, orderID, text)
+}
+
+// Sales mocks base method.
+func (m *MockOrders) Sales(ctx context.Context) ([]orders.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sales", ctx)
+	ret0, _ := ret[0].([]orders.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockOrdersMockRecorder) Sales(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockOrders)(nil).Sales), ctx)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kostromin59/funpay/settings (interfaces: Settings)
+//
+// Generated by this command:
+//
+//	mockgen -destination ../mocks/settings.go -package mocks . Settings
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	settings "github.com/kostromin59/funpay/settings"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSettings is a mock of Settings interface.
+type MockSettings struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingsMockRecorder
+	isgomock struct{}
+}
+
+// MockSettingsMockRecorder is the mock recorder for MockSettings.
+type MockSettingsMockRecorder struct {
+	mock *MockSettings
+}
+
+// NewMockSettings creates a new mock instance.
+func NewMockSettings(ctrl *gomock.Controller) *MockSettings {
+	mock := &MockSettings{ctrl: ctrl}
+	mock.recorder = &MockSettingsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettings) EXPECT() *MockSettingsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSettings) Get(ctx context.Context) (settings.Fields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx)
+	ret0, _ := ret[0].(settings.Fields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSettingsMockRecorder) Get(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSettings)(nil).Get), ctx)
+}
+
+// Save mocks base method.
+func (m *MockSettings) Save(ctx context.Context, fields settings.Fields) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSettingsMockRecorder) Save(ctx, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSettings)(nil).Save), ctx, fields)
+}
+
+// SetAway mocks base method.
+func (m *MockSettings) SetAway(ctx context.Context, away bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAway", ctx, away)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAway indicates an expected call of SetAway.
+func (mr *MockSettingsMockRecorder) SetAway(ctx, away any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAway", reflect.TypeOf((*MockSettings)(nil).SetAway), ctx, away)
+}
@@ -0,0 +1,279 @@
+package funpay
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalidMoney indicates that string can not be parsed as [Money].
+	ErrInvalidMoney = errors.New("invalid money")
+
+	// ErrCurrencyMismatch indicates operation on [Money] with different currencies.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+
+	// ErrUnsupportedCurrency indicates that Funpay does not support the currency.
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// Currency represents Funpay currency code.
+type Currency string
+
+const (
+	CurrencyRUB Currency = "RUB"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+)
+
+// Valid reports whether Funpay supports the currency.
+func (c Currency) Valid() bool {
+	switch c {
+	case CurrencyRUB, CurrencyUSD, CurrencyEUR:
+		return true
+	}
+
+	return false
+}
+
+// Sign returns currency sign used on Funpay pages (₽, $, €). Returns code for unknown currencies.
+func (c Currency) Sign() string {
+	switch c {
+	case CurrencyRUB:
+		return "₽"
+	case CurrencyUSD:
+		return "$"
+	case CurrencyEUR:
+		return "€"
+	}
+
+	return string(c)
+}
+
+// currencySigns maps signs and codes used on Funpay pages to [Currency].
+var currencySigns = map[string]Currency{
+	"₽":   CurrencyRUB,
+	"руб": CurrencyRUB,
+	"rub": CurrencyRUB,
+	"$":   CurrencyUSD,
+	"usd": CurrencyUSD,
+	"€":   CurrencyEUR,
+	"eur": CurrencyEUR,
+}
+
+// Money represents amount in minor units (kopecks, cents) with currency.
+// Currency may be empty if it is unknown (e.g. lot price field).
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+// NewMoney creates [Money] from major and minor units: NewMoney(10, 50, CurrencyRUB) is 10.50 ₽.
+func NewMoney(major, minor int64, currency Currency) Money {
+	if major < 0 {
+		minor = -minor
+	}
+
+	return Money{Amount: major*100 + minor, Currency: currency}
+}
+
+// ParseMoney parses money from Funpay pages in both locales: "1 234,56 ₽", "1,234.56 $", "− 100.5 €", "+ 10 руб.".
+// The last separator followed by one or two digits is decimal, other separators and spaces are ignored.
+// Currency is empty if string has no currency sign.
+func ParseMoney(s string) (Money, error) {
+	const op = "funpay.ParseMoney"
+
+	var number strings.Builder
+	var sign strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsDigit(r), r == ',', r == '.', r == '-', r == '+':
+			number.WriteRune(r)
+		case r == '−':
+			number.WriteRune('-')
+		case unicode.IsSpace(r):
+		default:
+			sign.WriteRune(r)
+		}
+	}
+
+	currency, ok := currencySigns[sign.String()]
+	if !ok && sign.Len() != 0 {
+		return Money{}, fmt.Errorf("%s: %w (%q)", op, ErrInvalidMoney, s)
+	}
+
+	amount, err := parseMinor(number.String())
+	if err != nil {
+		return Money{}, fmt.Errorf("%s: %w (%q)", op, ErrInvalidMoney, s)
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// parseMinor converts decimal number into minor units.
+func parseMinor(s string) (int64, error) {
+	if !strings.ContainsFunc(s, unicode.IsDigit) {
+		return 0, ErrInvalidMoney
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+
+	integer, fraction := s, ""
+	if i := strings.LastIndexAny(s, ",."); i != -1 && len(s)-i-1 <= 2 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+
+	integer = strings.NewReplacer(",", "", ".", "").Replace(integer)
+	if integer == "" {
+		integer = "0"
+	}
+
+	fraction = (fraction + "00")[:2]
+
+	amount, err := strconv.ParseInt(integer+fraction, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// String formats money as 1234.56 RUB. Result can be parsed by [ParseMoney].
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+
+	return m.Decimal() + " " + string(m.Currency)
+}
+
+// Decimal formats amount without currency as 1234.56. It is the format of lot price field.
+func (m Money) Decimal() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// Format formats money like Funpay pages in provided locale: "1 234,56 ₽" for [LocaleRU] and "1,234.56 $" for [LocaleEN].
+// Zero fraction is omitted.
+func (m Money) Format(locale Locale) string {
+	thousands, decimal := " ", ","
+	if locale == LocaleEN {
+		thousands, decimal = ",", "."
+	}
+
+	amount := m.Amount
+	var b strings.Builder
+	if amount < 0 {
+		b.WriteString("-")
+		amount = -amount
+	}
+
+	integer := strconv.FormatInt(amount/100, 10)
+	for i, r := range integer {
+		if i != 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(r)
+	}
+
+	if fraction := amount % 100; fraction != 0 {
+		b.WriteString(decimal)
+		b.WriteString(strings.TrimSuffix(fmt.Sprintf("%02d", fraction), "0"))
+	}
+
+	if m.Currency != "" {
+		b.WriteString(" ")
+		b.WriteString(m.Currency.Sign())
+	}
+
+	return b.String()
+}
+
+// IsZero reports whether amount equals 0.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports whether amount is less than 0.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Add returns sum of money. Returns [ErrCurrencyMismatch] if currencies differ.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.currency(other)
+	if err != nil {
+		return Money{}, fmt.Errorf("Money.Add: %w", err)
+	}
+
+	return Money{Amount: m.Amount + other.Amount, Currency: currency}, nil
+}
+
+// Sub returns difference of money. Returns [ErrCurrencyMismatch] if currencies differ.
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.currency(other)
+	if err != nil {
+		return Money{}, fmt.Errorf("Money.Sub: %w", err)
+	}
+
+	return Money{Amount: m.Amount - other.Amount, Currency: currency}, nil
+}
+
+// Mul returns money multiplied by n.
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Percent returns percent of money rounded half away from zero (e.g. commission).
+func (m Money) Percent(percent float64) Money {
+	value := float64(m.Amount) * percent / 100
+	if value < 0 {
+		value -= 0.5
+	} else {
+		value += 0.5
+	}
+
+	return Money{Amount: int64(value), Currency: m.Currency}
+}
+
+// Cmp compares money: -1 if m < other, 0 if m == other, +1 if m > other.
+// Returns [ErrCurrencyMismatch] if currencies differ.
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := m.currency(other); err != nil {
+		return 0, fmt.Errorf("Money.Cmp: %w", err)
+	}
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+// currency returns common currency of money. Empty currency is compatible with any currency.
+func (m Money) currency(other Money) (Currency, error) {
+	switch {
+	case m.Currency == other.Currency, other.Currency == "":
+		return m.Currency, nil
+	case m.Currency == "":
+		return other.Currency, nil
+	}
+
+	return "", fmt.Errorf("%w (%s, %s)", ErrCurrencyMismatch, m.Currency, other.Currency)
+}
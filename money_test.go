@@ -0,0 +1,115 @@
+package funpay_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+func TestParseMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in       string
+		expected funpay.Money
+	}{
+		{in: "1 234,56 ₽", expected: funpay.Money{Amount: 123456, Currency: funpay.CurrencyRUB}},
+		{in: "1,234.56 $", expected: funpay.Money{Amount: 123456, Currency: funpay.CurrencyUSD}},
+		{in: "100 руб.", expected: funpay.Money{Amount: 10000, Currency: funpay.CurrencyRUB}},
+		{in: "− 100.5 €", expected: funpay.Money{Amount: -10050, Currency: funpay.CurrencyEUR}},
+		{in: "+ 10 USD", expected: funpay.Money{Amount: 1000, Currency: funpay.CurrencyUSD}},
+		{in: "1.234 ₽", expected: funpay.Money{Amount: 123400, Currency: funpay.CurrencyRUB}},
+		{in: "0.5", expected: funpay.Money{Amount: 50}},
+	}
+
+	for _, tt := range tests {
+		money, err := funpay.ParseMoney(tt.in)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.in, err)
+		}
+
+		if money != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.in, tt.expected, money)
+		}
+	}
+
+	for _, in := range []string{"", "₽", "10 tokens"} {
+		if _, err := funpay.ParseMoney(in); !errors.Is(err, funpay.ErrInvalidMoney) {
+			t.Errorf("%q: expected ErrInvalidMoney, got %v", in, err)
+		}
+	}
+}
+
+func TestMoney_Format(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		money    funpay.Money
+		locale   funpay.Locale
+		expected string
+	}{
+		{money: funpay.NewMoney(1234, 56, funpay.CurrencyRUB), locale: funpay.LocaleRU, expected: "1 234,56 ₽"},
+		{money: funpay.NewMoney(1234567, 50, funpay.CurrencyUSD), locale: funpay.LocaleEN, expected: "1,234,567.5 $"},
+		{money: funpay.NewMoney(-100, 0, funpay.CurrencyEUR), locale: funpay.LocaleRU, expected: "-100 €"},
+		{money: funpay.NewMoney(0, 5, ""), locale: funpay.LocaleEN, expected: "0.05"},
+	}
+
+	for _, tt := range tests {
+		if formatted := tt.money.Format(tt.locale); formatted != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, formatted)
+		}
+	}
+
+	money := funpay.NewMoney(-12, 5, funpay.CurrencyRUB)
+	if money.String() != "-12.05 RUB" {
+		t.Errorf("unexpected string: %q", money.String())
+	}
+
+	parsed, err := funpay.ParseMoney(money.String())
+	if err != nil || parsed != money {
+		t.Errorf("expected %v, got %v (%v)", money, parsed, err)
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	a := funpay.NewMoney(10, 50, funpay.CurrencyRUB)
+	b := funpay.NewMoney(2, 75, funpay.CurrencyRUB)
+
+	sum, err := a.Add(b)
+	if err != nil || sum != funpay.NewMoney(13, 25, funpay.CurrencyRUB) {
+		t.Errorf("unexpected sum: %v (%v)", sum, err)
+	}
+
+	diff, err := b.Sub(a)
+	if err != nil || diff != funpay.NewMoney(-7, 75, funpay.CurrencyRUB) || !diff.IsNegative() {
+		t.Errorf("unexpected difference: %v (%v)", diff, err)
+	}
+
+	if product := a.Mul(3); product != funpay.NewMoney(31, 50, funpay.CurrencyRUB) {
+		t.Errorf("unexpected product: %v", product)
+	}
+
+	if percent := a.Percent(5); percent != funpay.NewMoney(0, 53, funpay.CurrencyRUB) {
+		t.Errorf("unexpected percent: %v", percent)
+	}
+
+	if cmp, err := a.Cmp(b); err != nil || cmp != 1 {
+		t.Errorf("expected 1, got %d (%v)", cmp, err)
+	}
+
+	if cmp, err := b.Cmp(funpay.Money{Amount: 275}); err != nil || cmp != 0 {
+		t.Errorf("expected 0, got %d (%v)", cmp, err)
+	}
+
+	usd := funpay.NewMoney(1, 0, funpay.CurrencyUSD)
+	if _, err := a.Add(usd); !errors.Is(err, funpay.ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	if _, err := a.Cmp(usd); !errors.Is(err, funpay.ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package orders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/lots"
+)
+
+var (
+	// ErrReviewFailed indicates that Funpay rejected reply to the review.
+	ErrReviewFailed = errors.New("review reply failed")
+)
+
+// OrderID represents ID of order without leading #.
+type OrderID string
+
+// Status represents order status.
+type Status string
+
+const (
+	// StatusPaid means that buyer paid the order and is waiting for it.
+	StatusPaid Status = "paid"
+	// StatusClosed means that buyer confirmed the order.
+	StatusClosed Status = "closed"
+	// StatusRefunded means that order was refunded.
+	StatusRefunded Status = "refunded"
+)
+
+// Order represents order from the sales page.
+// NodeID is filled only by [Orders.Get], Status, Date, Description, Subcategory and Price only by [Orders.Sales].
+type Order struct {
+	ID          OrderID
+	Status      Status
+	Date        string
+	Description string
+	Subcategory string
+
+	// Price is zero if it can not be parsed.
+	Price funpay.Money
+
+	NodeID lots.NodeID
+
+	BuyerID   int64
+	BuyerName string
+	SellerID  int64
+}
+
+//go:generate go tool mockgen -destination ../mocks/orders.go -package mocks . Orders
+type Orders interface {
+	// Sales loads the first page of orders where account is the seller (/orders/trade).
+	// Returns [funpay.ErrAccountUnauthorized] if user id equals 0.
+	Sales(ctx context.Context) ([]Order, error)
+
+	// Get loads order page and returns order with filled [Order.NodeID].
+	Get(ctx context.Context, orderID OrderID) (Order, error)
+
+	// ReplyReview replies to the buyer's review of the order. Existing reply is replaced.
+	// Returns [ErrReviewFailed] if Funpay responded with error.
+	ReplyReview(ctx context.Context, orderID OrderID, text string) error
+}
+
+type OrdersClient struct {
+	fp funpay.Funpay
+}
+
+func New(fp funpay.Funpay) Orders {
+	return &OrdersClient{
+		fp: fp,
+	}
+}
+
+func (o *OrdersClient) Sales(ctx context.Context) ([]Order, error) {
+	const op = "OrdersClient.Sales"
+
+	sellerID := o.fp.UserID()
+	if sellerID == 0 {
+		return nil, fmt.Errorf("%s: %w", op, funpay.ErrAccountUnauthorized)
+	}
+
+	reqURL, err := funpay.URL("orders", "trade").WithBaseURL(o.fp.BaseURL()).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := o.fp.RequestHTML(ctx, reqURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return extractOrders(doc, sellerID), nil
+}
+
+func (o *OrdersClient) Get(ctx context.Context, orderID OrderID) (Order, error) {
+	const op = "OrdersClient.Get"
+
+	reqURL, err := funpay.URL("orders", string(orderID), "/").WithBaseURL(o.fp.BaseURL()).Parse()
+	if err != nil {
+		return Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := o.fp.RequestHTML(ctx, reqURL.String())
+	if err != nil {
+		return Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	order := Order{
+		ID:       orderID,
+		SellerID: o.fp.UserID(),
+	}
+
+	for _, link := range doc.Find(".param-item a[href]").EachIter() {
+		href := link.AttrOr("href", "")
+
+		if nodeID, _, ok := lots.ParseNodeLink(href); ok && order.NodeID == "" {
+			order.NodeID = nodeID
+			continue
+		}
+
+		if userID := parseUserID(href); userID != 0 && userID != order.SellerID && order.BuyerID == 0 {
+			order.BuyerID = userID
+			order.BuyerName = strings.TrimSpace(link.Text())
+		}
+	}
+
+	return order, nil
+}
+
+// reviewResponse represents response of /orders/review endpoint.
+// Error is either null, boolean, number or text of the error.
+type reviewResponse struct {
+	Error any    `json:"error"`
+	Msg   string `json:"msg"`
+}
+
+func (o *OrdersClient) ReplyReview(ctx context.Context, orderID OrderID, text string) error {
+	const op = "OrdersClient.ReplyReview"
+
+	body := url.Values{}
+	body.Set("authorId", strconv.FormatInt(o.fp.UserID(), 10))
+	body.Set("text", text)
+	body.Set("rating", "")
+	body.Set("orderId", string(orderID))
+	body.Set(funpay.FormCSRFToken, o.fp.CSRFToken())
+
+	resp, err := o.fp.Request(ctx, funpay.URL("orders", "review").WithBaseURL(o.fp.BaseURL()).String(),
+		funpay.RequestWithMethod(http.MethodPost),
+		funpay.RequestWithBody(bytes.NewBufferString(body.Encode())),
+		funpay.RequestWithHeaders(funpay.RequestPostHeaders),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	var reviewResp reviewResponse
+	if err := json.NewDecoder(resp.Body).Decode(&reviewResp); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch e := reviewResp.Error.(type) {
+	case nil:
+	case bool:
+		if e {
+			return fmt.Errorf("%s: %w (%s)", op, ErrReviewFailed, reviewResp.Msg)
+		}
+	case float64:
+		if e != 0 {
+			return fmt.Errorf("%s: %w (%s)", op, ErrReviewFailed, reviewResp.Msg)
+		}
+	case string:
+		if e != "" {
+			return fmt.Errorf("%s: %w (%s)", op, ErrReviewFailed, e)
+		}
+	}
+
+	return nil
+}
+
+func extractOrders(doc *goquery.Document, sellerID int64) []Order {
+	items := doc.Find("a.tc-item")
+	orders := make([]Order, 0, items.Length())
+	for _, item := range items.EachIter() {
+		id := strings.TrimPrefix(strings.TrimSpace(item.Find(".tc-order").Text()), "#")
+		if id == "" {
+			continue
+		}
+
+		user := item.Find(".tc-user .media-user-name [data-href], .tc-user .avatar-photo[data-href]").First()
+		price, _ := funpay.ParseMoney(item.Find(".tc-price").Text())
+
+		orders = append(orders, Order{
+			ID:          OrderID(id),
+			Status:      parseStatus(item.Find(".tc-status")),
+			Date:        strings.TrimSpace(item.Find(".tc-date-time").Text()),
+			Description: strings.TrimSpace(item.Find(".order-desc > div").First().Text()),
+			Subcategory: strings.TrimSpace(item.Find(".order-desc .text-muted").Text()),
+			Price:       price,
+			BuyerID:     parseUserID(user.AttrOr("data-href", "")),
+			BuyerName:   strings.TrimSpace(item.Find(".tc-user .media-user-name").Text()),
+			SellerID:    sellerID,
+		})
+	}
+
+	return orders
+}
+
+// parseStatus detects status by the class of status element.
+func parseStatus(s *goquery.Selection) Status {
+	switch {
+	case s.HasClass("text-primary"):
+		return StatusPaid
+	case s.HasClass("text-success"):
+		return StatusClosed
+	case s.HasClass("text-warning"):
+		return StatusRefunded
+	}
+
+	return ""
+}
+
+// parseUserID parses links like /users/{id}/. Returns 0 if link is invalid.
+func parseUserID(href string) int64 {
+	rawID, ok := funpay.LinkID(href, "users")
+	if !ok {
+		return 0
+	}
+
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
@@ -0,0 +1,213 @@
+package orders_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/mocks"
+	"github.com/kostromin59/funpay/orders"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrders_Sales(t *testing.T) {
+	t.Parallel()
+	t.Run("successful orders retrieval", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpOrders := orders.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+			<body data-app-data='{"userId":1,"csrf-token":"test","locale":"ru"}'>
+				<a href="https://funpay.com/orders/ABCD1234/" class="tc-item info">
+					<div class="tc-date"><div class="tc-date-time">10 апреля, 12:00</div></div>
+					<div class="tc-order">#ABCD1234</div>
+					<div class="order-desc"><div>Steam key</div><div class="text-muted">Steam, Ключи</div></div>
+					<div class="tc-user">
+						<div class="media media-user">
+							<div class="media-left"><div class="avatar-photo pseudo-a" data-href="https://funpay.com/users/25/"></div></div>
+							<div class="media-body"><div class="media-user-name"><span class="pseudo-a" data-href="https://funpay.com/users/25/">buyer</span></div></div>
+						</div>
+					</div>
+					<div class="tc-status text-primary">Оплачен</div>
+					<div class="tc-price">100 <span class="unit">₽</span></div>
+				</a>
+				<a href="https://funpay.com/orders/QWER/" class="tc-item">
+					<div class="tc-order">#QWER</div>
+					<div class="tc-status text-success">Закрыт</div>
+				</a>
+			</body>
+		</html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().UserID().Times(1).Return(int64(1))
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/orders/trade").Times(1).Return(doc, nil)
+
+		sales, err := fpOrders.Sales(t.Context())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []orders.Order{
+			{
+				ID:          "ABCD1234",
+				Status:      orders.StatusPaid,
+				Date:        "10 апреля, 12:00",
+				Description: "Steam key",
+				Subcategory: "Steam, Ключи",
+				Price:       funpay.NewMoney(100, 0, funpay.CurrencyRUB),
+				BuyerID:     25,
+				BuyerName:   "buyer",
+				SellerID:    1,
+			},
+			{
+				ID:       "QWER",
+				Status:   orders.StatusClosed,
+				SellerID: 1,
+			},
+		}
+
+		if !reflect.DeepEqual(sales, expected) {
+			t.Errorf("expected %+v, got %+v", expected, sales)
+		}
+	})
+
+	t.Run("unauthorized user", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpOrders := orders.New(fp)
+
+		fp.EXPECT().UserID().Times(1).Return(int64(0))
+
+		_, err := fpOrders.Sales(t.Context())
+		if !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Fatalf("expected ErrAccountUnauthorized, got %v", err)
+		}
+	})
+}
+
+func TestOrders_Get(t *testing.T) {
+	t.Parallel()
+	t.Run("successful order retrieval", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpOrders := orders.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+			<body data-app-data='{"userId":1,"csrf-token":"test","locale":"ru"}'>
+				<div class="param-item"><h5>Игра</h5><div><a href="https://funpay.com/lots/1000/">Steam</a></div></div>
+				<div class="param-item"><h5>Продавец</h5><div><a href="https://funpay.com/users/1/">seller</a></div></div>
+				<div class="param-item"><h5>Покупатель</h5><div><a href="https://funpay.com/users/25/">buyer</a></div></div>
+			</body>
+		</html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().UserID().Times(1).Return(int64(1))
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/orders/ABCD1234/").Times(1).Return(doc, nil)
+
+		order, err := fpOrders.Get(t.Context(), "ABCD1234")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := orders.Order{
+			ID:        "ABCD1234",
+			NodeID:    "1000",
+			BuyerID:   25,
+			BuyerName: "buyer",
+			SellerID:  1,
+		}
+
+		if !reflect.DeepEqual(order, expected) {
+			t.Errorf("expected %+v, got %+v", expected, order)
+		}
+	})
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpOrders := orders.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return(":not a url")
+
+		if _, err := fpOrders.Get(t.Context(), "ABCD1234"); err == nil {
+			t.Fatal("expected error for invalid URL, got nil")
+		}
+	})
+}
+
+func TestOrders_ReplyReview(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		response string
+		err      error
+	}{
+		{
+			name:     "successful reply",
+			response: `{"content":"<div class=\"review-item\"></div>"}`,
+		},
+		{
+			name:     "funpay error",
+			response: `{"error":1,"msg":"Review not found"}`,
+			err:      orders.ErrReviewFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fp := mocks.NewMockFunpay(ctrl)
+			fpOrders := orders.New(fp)
+
+			fp.EXPECT().UserID().Times(1).Return(int64(1))
+			fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+			fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+			fp.EXPECT().Request(
+				t.Context(),
+				"https://funpay.com/orders/review",
+				gomock.Any(),
+			).Times(1).Return(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(tt.response)),
+			}, nil)
+
+			err := fpOrders.ReplyReview(t.Context(), "ABCD", "Thanks!")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
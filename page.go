@@ -0,0 +1,90 @@
+package funpay
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// ErrMaintenance indicates that Funpay shows maintenance page instead of requested one.
+	// Back off and retry later.
+	ErrMaintenance = errors.New("funpay is under maintenance")
+
+	// ErrChallenge indicates that request is stopped by Cloudflare or DDoS-Guard browser challenge.
+	// Usually caused by proxy or request rate, not by golden key.
+	ErrChallenge = errors.New("anti-bot challenge")
+
+	// ErrCaptcha indicates that Funpay or Cloudflare requires captcha to continue.
+	ErrCaptcha = errors.New("captcha required")
+)
+
+// challengeSelectors match Cloudflare and DDoS-Guard browser challenge pages.
+var challengeSelectors = []string{
+	"#challenge-form",
+	"#challenge-running",
+	"#challenge-stage",
+	`script[src*="/cdn-cgi/challenge-platform/"]`,
+	`script[src*="ddos-guard"]`,
+	`link[href*="ddos-guard"]`,
+}
+
+// captchaSelectors match captcha widgets.
+var captchaSelectors = []string{
+	".g-recaptcha",
+	".h-captcha",
+	".cf-turnstile",
+	`iframe[src*="captcha"]`,
+	`form[action*="captcha"]`,
+}
+
+// challengeTitles are lowercased titles of challenge pages.
+var challengeTitles = []string{"just a moment", "ddos-guard", "checking your browser"}
+
+// maintenanceMarkers are lowercased texts of maintenance pages.
+var maintenanceMarkers = []string{"технические работы", "техническое обслуживание", "maintenance"}
+
+// classifyPage returns [ErrChallenge], [ErrCaptcha] or [ErrMaintenance] if the page is not a regular Funpay page.
+// Returns nil if the page is not recognized.
+func classifyPage(resp *http.Response, doc *goquery.Document) error {
+	title := strings.ToLower(strings.TrimSpace(doc.Find("title").First().Text()))
+
+	if resp.Header.Get("cf-mitigated") == "challenge" || containsAny(title, challengeTitles) {
+		return ErrChallenge
+	}
+
+	for _, selector := range challengeSelectors {
+		if doc.Find(selector).Length() != 0 {
+			return ErrChallenge
+		}
+	}
+
+	for _, selector := range captchaSelectors {
+		if doc.Find(selector).Length() != 0 {
+			return ErrCaptcha
+		}
+	}
+
+	if containsAny(title, maintenanceMarkers) {
+		return ErrMaintenance
+	}
+
+	text := strings.ToLower(doc.Find("body").Text())
+	if containsAny(text, maintenanceMarkers) {
+		return ErrMaintenance
+	}
+
+	return nil
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+
+	return false
+}
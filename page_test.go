@@ -0,0 +1,99 @@
+package funpay_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+func TestFunpay_RequestHTML_Pages(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status   int
+		headers  map[string]string
+		body     string
+		expected error
+		httpErr  bool
+	}{
+		"cloudflare challenge": {
+			status:   http.StatusForbidden,
+			headers:  map[string]string{"cf-mitigated": "challenge"},
+			body:     `<html><head><title>Just a moment...</title></head><body></body></html>`,
+			expected: funpay.ErrChallenge,
+			httpErr:  true,
+		},
+		"ddos-guard challenge": {
+			status:   http.StatusOK,
+			body:     `<html><head><title>DDoS-Guard</title></head><body><script src="/.well-known/ddos-guard/check"></script></body></html>`,
+			expected: funpay.ErrChallenge,
+		},
+		"captcha": {
+			status:   http.StatusOK,
+			body:     `<html><body><form><div class="g-recaptcha" data-sitekey="key"></div></form></body></html>`,
+			expected: funpay.ErrCaptcha,
+		},
+		"maintenance": {
+			status:   http.StatusServiceUnavailable,
+			body:     `<html><body><h1>На сайте ведутся технические работы</h1></body></html>`,
+			expected: funpay.ErrMaintenance,
+			httpErr:  true,
+		},
+		"unknown page without app data": {
+			status:   http.StatusOK,
+			body:     `<html><body></body></html>`,
+			expected: funpay.ErrAccountUnauthorized,
+		},
+		"unauthorized status": {
+			status:   http.StatusForbidden,
+			body:     `<html><body>Forbidden</body></html>`,
+			expected: funpay.ErrAccountUnauthorized,
+			httpErr:  true,
+		},
+		"regular page with captcha": {
+			status: http.StatusOK,
+			body:   `<html><body data-app-data='{"userId":1,"csrf-token":"test","locale":"ru"}'><div class="g-recaptcha"></div></body></html>`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for name, value := range tt.headers {
+					w.Header().Set(name, value)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			fp := funpay.New("test_key", "test_agent")
+
+			_, err := fp.RequestHTML(t.Context(), ts.URL)
+			if tt.expected == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+
+			if tt.expected != funpay.ErrAccountUnauthorized && errors.Is(err, funpay.ErrAccountUnauthorized) {
+				t.Errorf("page error must not match ErrAccountUnauthorized: %v", err)
+			}
+
+			var httpErr *funpay.HTTPError
+			if errors.As(err, &httpErr) != tt.httpErr {
+				t.Errorf("expected *HTTPError: %t, got %v", tt.httpErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package funpay
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Profile represents the header of user profile page (/users/{id}/).
+type Profile struct {
+	Username  string
+	AvatarURL string
+	Online    bool
+
+	// LastSeen is the status as shown on the page (e.g. "Был 5 минут назад"). Empty if the user is online.
+	LastSeen string
+
+	// Registered is the registration date as shown on the page.
+	Registered string
+
+	Rating       float64
+	ReviewsCount int
+	Banned       bool
+}
+
+// ParseProfile parses the header of user profile page. Returns false if the page is not a profile page.
+func ParseProfile(doc *goquery.Document) (Profile, bool) {
+	header := doc.Find(".profile-header").First()
+	if header.Length() == 0 {
+		return Profile{}, false
+	}
+
+	username := strings.TrimSpace(header.Find(".media-user-name .mr4").First().Text())
+	if username == "" {
+		username = strings.TrimSpace(header.Find(".media-user-name").First().Text())
+	}
+
+	profile := Profile{
+		Username:     username,
+		Online:       header.Find(".media-user").HasClass("online"),
+		Rating:       parseRating(doc.Find(".rating-value .big").First().Text()),
+		ReviewsCount: parseCount(doc.Find(".rating-full-count").First().Text()),
+		Banned:       header.Find(".label-danger").Length() != 0,
+	}
+
+	if style, ok := header.Find(".avatar-photo").First().Attr("style"); ok {
+		profile.AvatarURL = backgroundURL(style)
+	}
+
+	if !profile.Online {
+		profile.LastSeen = strings.TrimSpace(header.Find(".media-user-status").First().Text())
+	}
+
+	doc.Find(".param-item").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		title := strings.ToLower(s.Find("h5").Text())
+		if strings.Contains(title, "регистрац") || strings.Contains(title, "registration") {
+			profile.Registered = strings.TrimSpace(s.Find(".text-nowrap").First().Text())
+			return false
+		}
+
+		return true
+	})
+
+	return profile, true
+}
+
+// backgroundURL returns URL from background-image style, e.g. "background-image: url(/img/avatar.jpg);".
+func backgroundURL(style string) string {
+	_, rest, ok := strings.Cut(style, "url(")
+	if !ok {
+		return ""
+	}
+
+	rawURL, _, ok := strings.Cut(rest, ")")
+	if !ok {
+		return ""
+	}
+
+	return strings.Trim(strings.TrimSpace(rawURL), `"'`)
+}
@@ -0,0 +1,315 @@
+package funpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnsupportedProxy indicates that proxy scheme is not http, https, socks5 or socks5h.
+	ErrUnsupportedProxy = errors.New("unsupported proxy")
+
+	// ErrNoProxies indicates that every proxy of the pool is marked bad.
+	ErrNoProxies = errors.New("no available proxies")
+)
+
+// ProxySelector chooses proxy for every request of [FunpayClient] (see [FunpayRequester.SetProxySelector]).
+type ProxySelector interface {
+	// Proxy returns proxy for the next request.
+	Proxy() (*url.URL, error)
+
+	// Report reports result of the request made through proxy.
+	// Err is nil on success, connection error or [ErrTooManyRequests] otherwise.
+	Report(proxy *url.URL, err error)
+}
+
+// ProxyChecker checks whether proxy is available.
+type ProxyChecker func(ctx context.Context, proxy *url.URL) error
+
+// ValidateProxy checks that proxy scheme is supported: http, https, socks5 or socks5h.
+func ValidateProxy(proxy *url.URL) error {
+	if proxy == nil || proxy.Host == "" {
+		return fmt.Errorf("%w (%v)", ErrUnsupportedProxy, proxy)
+	}
+
+	switch proxy.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return nil
+	}
+
+	return fmt.Errorf("%w (%s)", ErrUnsupportedProxy, proxy.Redacted())
+}
+
+type proxyState struct {
+	url        *url.URL
+	failures   int
+	badUntil   time.Time
+	lastFailed error
+}
+
+// ProxyPool rotates proxies on every request and excludes bad proxies.
+//
+// Proxy is marked bad after [ProxyPoolWithMaxFailures] consecutive failures (connection errors or 429)
+// for [ProxyPoolWithCooldown]. After cooldown it is used again or re-tested by [ProxyPool.Check].
+// Use [ProxyPool.Sticky] to use the same proxy until it fails.
+type ProxyPool struct {
+	proxies     []*proxyState
+	next        int
+	maxFailures int
+	cooldown    time.Duration
+	checker     ProxyChecker
+	now         func() time.Time
+	mu          sync.Mutex
+}
+
+// ProxyPoolOpt defines a function type for modifying [ProxyPool].
+type ProxyPoolOpt func(pool *ProxyPool)
+
+// ProxyPoolWithMaxFailures sets count of consecutive failures to mark proxy bad.
+// Default: 1
+func ProxyPoolWithMaxFailures(maxFailures int) ProxyPoolOpt {
+	return func(pool *ProxyPool) {
+		pool.maxFailures = max(maxFailures, 1)
+	}
+}
+
+// ProxyPoolWithCooldown sets duration while bad proxy is not used.
+// Default: 1 minute
+func ProxyPoolWithCooldown(cooldown time.Duration) ProxyPoolOpt {
+	return func(pool *ProxyPool) {
+		pool.cooldown = cooldown
+	}
+}
+
+// ProxyPoolWithChecker sets checker used by [ProxyPool.Check].
+// Default: TCP connection to the proxy host.
+func ProxyPoolWithChecker(checker ProxyChecker) ProxyPoolOpt {
+	return func(pool *ProxyPool) {
+		pool.checker = checker
+	}
+}
+
+// NewProxyPool creates [ProxyPool]. Returns [ErrUnsupportedProxy] if any proxy is invalid (see [ValidateProxy]).
+func NewProxyPool(proxies []*url.URL, opts ...ProxyPoolOpt) (*ProxyPool, error) {
+	const op = "funpay.NewProxyPool"
+
+	pool := &ProxyPool{
+		proxies:     make([]*proxyState, 0, len(proxies)),
+		maxFailures: 1,
+		cooldown:    time.Minute,
+		checker:     dialProxy,
+		now:         time.Now,
+	}
+
+	for _, proxy := range proxies {
+		if err := ValidateProxy(proxy); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		pool.proxies = append(pool.proxies, &proxyState{url: proxy})
+	}
+
+	for _, opt := range opts {
+		opt(pool)
+	}
+
+	return pool, nil
+}
+
+// Proxy returns the next available proxy. Returns [ErrNoProxies] if every proxy is bad.
+func (p *ProxyPool) Proxy() (*url.URL, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	for range p.proxies {
+		state := p.proxies[p.next%len(p.proxies)]
+		p.next = (p.next + 1) % len(p.proxies)
+
+		if !now.Before(state.badUntil) {
+			return state.url, nil
+		}
+	}
+
+	return nil, ErrNoProxies
+}
+
+// Report counts failures of proxy and marks it bad (see [ProxyPool]).
+func (p *ProxyPool) Report(proxy *url.URL, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state := p.state(proxy)
+	if state == nil {
+		return
+	}
+
+	if err == nil {
+		state.failures = 0
+		state.lastFailed = nil
+		return
+	}
+
+	state.failures++
+	state.lastFailed = err
+	if state.failures >= p.maxFailures {
+		state.badUntil = p.now().Add(p.cooldown)
+	}
+}
+
+// Bad returns proxies which are marked bad now.
+func (p *ProxyPool) Bad() []*url.URL {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var bad []*url.URL
+	for _, state := range p.proxies {
+		if now.Before(state.badUntil) {
+			bad = append(bad, state.url)
+		}
+	}
+
+	return bad
+}
+
+// Check re-tests proxies marked bad or failed recently with [ProxyChecker] and restores available ones.
+// Unavailable proxies stay bad for the cooldown, the returned error lists each of them with its check error.
+func (p *ProxyPool) Check(ctx context.Context) error {
+	const op = "ProxyPool.Check"
+
+	p.mu.Lock()
+	var failed []*url.URL
+	for _, state := range p.proxies {
+		if state.failures != 0 {
+			failed = append(failed, state.url)
+		}
+	}
+	p.mu.Unlock()
+
+	var errs []error
+	for _, proxy := range failed {
+		err := p.checker(ctx, proxy)
+
+		p.mu.Lock()
+		if state := p.state(proxy); state != nil {
+			if err == nil {
+				state.failures = 0
+				state.lastFailed = nil
+				state.badUntil = time.Time{}
+			} else {
+				state.lastFailed = err
+				state.badUntil = p.now().Add(p.cooldown)
+			}
+		}
+		p.mu.Unlock()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", proxy.Redacted(), err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Run re-checks proxies every interval until context is done, so bad proxies return to the pool once available.
+// Errors of [ProxyPool.Check] are passed to onError if it is not nil. Returns [ErrInvalidInterval] if interval is not positive.
+func (p *ProxyPool) Run(ctx context.Context, interval time.Duration, onError func(err error)) error {
+	return RunEvery(ctx, interval, p.Check, onError)
+}
+
+// Sticky returns [ProxySelector] which uses the same proxy of the pool until it is marked bad.
+// Use one sticky selector per account to keep its IP address stable.
+func (p *ProxyPool) Sticky() ProxySelector {
+	return &stickyProxy{pool: p}
+}
+
+func (p *ProxyPool) state(proxy *url.URL) *proxyState {
+	if proxy == nil {
+		return nil
+	}
+
+	i := slices.IndexFunc(p.proxies, func(state *proxyState) bool {
+		return state.url.String() == proxy.String()
+	})
+	if i == -1 {
+		return nil
+	}
+
+	return p.proxies[i]
+}
+
+// isBad reports whether proxy is marked bad now.
+func (p *ProxyPool) isBad(proxy *url.URL) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state := p.state(proxy)
+
+	return state == nil || p.now().Before(state.badUntil)
+}
+
+type stickyProxy struct {
+	pool    *ProxyPool
+	current *url.URL
+	mu      sync.Mutex
+}
+
+func (s *stickyProxy) Proxy() (*url.URL, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current != nil && !s.pool.isBad(s.current) {
+		return s.current, nil
+	}
+
+	proxy, err := s.pool.Proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	s.current = proxy
+
+	return proxy, nil
+}
+
+func (s *stickyProxy) Report(proxy *url.URL, err error) {
+	s.pool.Report(proxy, err)
+}
+
+// dialProxy is default [ProxyChecker], it opens TCP connection to the proxy host.
+func dialProxy(ctx context.Context, proxy *url.URL) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyAddr(proxy))
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// proxyAddr returns host:port of proxy with default port of the scheme.
+func proxyAddr(proxy *url.URL) string {
+	if proxy.Port() != "" {
+		return proxy.Host
+	}
+
+	switch proxy.Scheme {
+	case "https":
+		return net.JoinHostPort(proxy.Hostname(), "443")
+	case "socks5", "socks5h":
+		return net.JoinHostPort(proxy.Hostname(), "1080")
+	}
+
+	return net.JoinHostPort(proxy.Hostname(), "80")
+}
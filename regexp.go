@@ -1,7 +0,0 @@
-package funpay
-
-import "regexp"
-
-var (
-	onlyDigitsRe = regexp.MustCompile(`\D`)
-)
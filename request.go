@@ -44,6 +44,7 @@ type RequestOpts struct {
 	cookies []*http.Cookie
 	headers map[string]string
 	proxy   *url.URL
+	locale  Locale
 }
 
 // NewRequestOpts creates request options with defaults:
@@ -89,9 +90,20 @@ func RequestWithHeaders(headers map[string]string) RequestOpt {
 }
 
 // RequestWithProxy overrides the account-level proxy for this request.
+// Supports the same schemes as [FunpayRequester.SetProxy]: http, https, socks5 and socks5h.
 // To disable proxy for single request: RequestWithProxy(nil)
 func RequestWithProxy(proxy *url.URL) RequestOpt {
 	return func(options *RequestOpts) {
 		options.proxy = proxy
 	}
 }
+
+// RequestWithLocale overrides the account locale for this GET request: path prefix of the locale is used instead of
+// the account one (see [Locale.PathPrefix]). The account locale is not changed by [FunpayRequester.RequestHTML],
+// so concurrent requests are not affected.
+// Returns [ErrUnsupportedLocale] if the locale is not registered (see [RegisterLocale]).
+func RequestWithLocale(locale Locale) RequestOpt {
+	return func(options *RequestOpts) {
+		options.locale = locale
+	}
+}
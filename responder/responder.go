@@ -0,0 +1,257 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/chat"
+	"github.com/kostromin59/funpay/orders"
+	"github.com/kostromin59/funpay/templates"
+)
+
+type cooldownKey struct {
+	chatID chat.ChatID
+	rule   int
+}
+
+// Responder replies to incoming chat messages using the first matched [Rule].
+//
+// The first call of [Responder.Poll] only remembers the last messages of existing chats,
+// so old messages are not answered after start. Message is marked as seen after the reply was sent
+// or no rule matched it, so failed replies are retried by the next [Responder.Poll].
+type Responder struct {
+	fp        funpay.FunpayUser
+	chat      chat.Chat
+	orders    orders.Orders
+	templates *templates.Set
+	rules     []compiledRule
+
+	lastSeen  map[chat.ChatID]int64
+	primed    bool
+	cooldowns map[cooldownKey]time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// New creates [Responder]. Orders may be nil, then [templates.Data.Order] is always nil.
+// Returns [ErrInvalidRule] if any rule is invalid.
+func New(fp funpay.FunpayUser, c chat.Chat, o orders.Orders, rules ...Rule) (*Responder, error) {
+	const op = "responder.New"
+
+	compiled := make([]compiledRule, 0, len(rules))
+	for i, rule := range rules {
+		r, err := compileRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		r.index = i
+		compiled = append(compiled, r)
+	}
+
+	return &Responder{
+		fp:        fp,
+		chat:      c,
+		orders:    o,
+		rules:     compiled,
+		lastSeen:  make(map[chat.ChatID]int64),
+		cooldowns: make(map[cooldownKey]time.Time),
+		now:       time.Now,
+	}, nil
+}
+
+// SetTemplates sets templates used by rules with [Rule.Template].
+func (r *Responder) SetTemplates(set *templates.Set) {
+	r.mu.Lock()
+	r.templates = set
+	r.mu.Unlock()
+}
+
+// Poll loads contacts and replies to new messages. A chat whose history can not be loaded or reply can not be sent
+// is checked again on the next poll, a message whose reply fails to render is skipped. Errors of all failed chats
+// are included into the returned one.
+// If sales can not be loaded, replies are sent without [templates.Data.Order] and the error is returned too.
+func (r *Responder) Poll(ctx context.Context) error {
+	const op = "Responder.Poll"
+
+	contacts, err := r.chat.Chats(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.primed {
+		for _, contact := range contacts {
+			r.lastSeen[contact.ID] = contact.LastMessageID
+		}
+
+		r.primed = true
+		return nil
+	}
+
+	var errs []error
+
+	var sales []orders.Order
+	var salesLoaded bool
+	loadSales := func() []orders.Order {
+		if r.orders == nil || salesLoaded {
+			return sales
+		}
+
+		salesLoaded = true
+		loaded, err := r.orders.Sales(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+
+		sales = loaded
+
+		return sales
+	}
+
+	for _, contact := range contacts {
+		last, ok := r.lastSeen[contact.ID]
+		if ok && contact.LastMessageID <= last {
+			continue
+		}
+
+		if err := r.respond(ctx, contact, last, loadSales); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Run checks chats every interval until context is done. Keep interval short (10-30 seconds) to reply in time.
+// Errors of [Responder.Poll] are passed to onError if it is not nil. Returns [funpay.ErrInvalidInterval]
+// if interval is not positive.
+func (r *Responder) Run(ctx context.Context, interval time.Duration, onError func(err error)) error {
+	return funpay.RunEvery(ctx, interval, r.Poll, onError)
+}
+
+func (r *Responder) respond(ctx context.Context, contact chat.Contact, last int64, loadSales func() []orders.Order) error {
+	const op = "Responder.respond"
+
+	history, err := r.chat.History(ctx, contact.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	account := templates.NewAccount(r.fp)
+
+	first := true
+	for _, msg := range history {
+		if msg.AuthorID == account.ID {
+			first = false
+		}
+	}
+
+	for _, msg := range history {
+		if msg.ID <= last {
+			continue
+		}
+
+		if msg.AuthorID == 0 || msg.AuthorID == account.ID {
+			r.markSeen(contact.ID, msg.ID)
+			continue
+		}
+
+		rule, ok := r.match(contact, msg, first)
+		if !ok {
+			r.markSeen(contact.ID, msg.ID)
+			continue
+		}
+
+		data := templates.Data{
+			Account: account,
+			Buyer: templates.Buyer{
+				ID:       msg.AuthorID,
+				Username: msg.Author,
+			},
+			Message: &msg,
+			Chat:    &contact,
+		}
+
+		for _, sale := range loadSales() {
+			if sale.BuyerID == msg.AuthorID {
+				data.Order = &sale
+				break
+			}
+		}
+
+		reply, err := r.render(rule, data)
+		if err != nil {
+			// Rendering fails the same way every time, the message is skipped to not report it forever.
+			r.markSeen(contact.ID, msg.ID)
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := r.chat.Send(ctx, contact.ID, reply); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		r.markSeen(contact.ID, msg.ID)
+		r.cooldowns[cooldownKey{chatID: contact.ID, rule: rule.index}] = r.now()
+		first = false
+	}
+
+	return nil
+}
+
+// markSeen marks message as handled, it is not answered again.
+func (r *Responder) markSeen(chatID chat.ChatID, msgID int64) {
+	r.lastSeen[chatID] = max(r.lastSeen[chatID], msgID)
+}
+
+// render executes [Rule.Template] in the language of the message (see [templates.DetectLocale])
+// or [Rule.Reply] if rule has no template.
+func (r *Responder) render(rule compiledRule, data templates.Data) (string, error) {
+	if rule.Template == "" {
+		var reply strings.Builder
+		if err := rule.reply.Execute(&reply, data); err != nil {
+			return "", err
+		}
+
+		return reply.String(), nil
+	}
+
+	if r.templates == nil {
+		return "", fmt.Errorf("%w (%s)", templates.ErrTemplateNotFound, rule.Template)
+	}
+
+	locale := templates.DetectLocale(data.Message.Text, data.Account.Locale)
+
+	return r.templates.Render(rule.Template, locale, data)
+}
+
+// match returns the first matched rule which is not on cooldown in the chat.
+func (r *Responder) match(contact chat.Contact, msg chat.Message, first bool) (compiledRule, bool) {
+	now := r.now()
+	for _, rule := range r.rules {
+		if !rule.match(contact, msg, first, now) {
+			continue
+		}
+
+		lastReply, ok := r.cooldowns[cooldownKey{chatID: contact.ID, rule: rule.index}]
+		if ok && now.Sub(lastReply) < time.Duration(rule.Cooldown) {
+			continue
+		}
+
+		return rule, true
+	}
+
+	return compiledRule{}, false
+}
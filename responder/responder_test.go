@@ -0,0 +1,310 @@
+package responder_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/chat"
+	"github.com/kostromin59/funpay/mocks"
+	"github.com/kostromin59/funpay/orders"
+	"github.com/kostromin59/funpay/responder"
+	"github.com/kostromin59/funpay/templates"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResponder_Poll(t *testing.T) {
+	t.Parallel()
+	t.Run("skips existing messages on start", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+
+		r, err := responder.New(fp, fpChat, nil, responder.Rule{Name: "any", Reply: "hi"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fpChat.EXPECT().Chats(t.Context()).Times(2).Return([]chat.Contact{
+			{ID: "100", LastMessageID: 5},
+		}, nil)
+
+		if err := r.Poll(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := r.Poll(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("greeting, commands and cooldown", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+		fpOrders := mocks.NewMockOrders(ctrl)
+
+		r, err := responder.New(fp, fpChat, fpOrders,
+			responder.Rule{Name: "greeting", FirstMessage: true, Reply: "Hello, {{.Message.Author}}! I am {{.Account.Username}}"},
+			responder.Rule{Name: "help", Command: "!help", Reply: "Commands: !help", Cooldown: responder.Duration(time.Hour)},
+			responder.Rule{Name: "order", Pattern: "(?i)order", Senders: []int64{25}, Reply: "{{with .Order}}Order #{{.ID}}{{else}}No orders{{end}}"},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fp.EXPECT().UserID().AnyTimes().Return(int64(1))
+		fp.EXPECT().Username().AnyTimes().Return("seller")
+		fp.EXPECT().Balance().AnyTimes().Return(funpay.Money{})
+		fp.EXPECT().Locale().AnyTimes().Return(funpay.LocaleRU)
+
+		gomock.InOrder(
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return(nil, nil),
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return([]chat.Contact{
+				{ID: "100", LastMessageID: 3},
+			}, nil),
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return([]chat.Contact{
+				{ID: "100", LastMessageID: 6},
+			}, nil),
+		)
+
+		gomock.InOrder(
+			fpChat.EXPECT().History(t.Context(), chat.ChatID("100")).Times(1).Return([]chat.Message{
+				{ID: 1, AuthorID: 25, Author: "buyer", Text: "hi"},
+				{ID: 2, AuthorID: 0, Text: "system message"},
+				{ID: 3, AuthorID: 25, Author: "buyer", Text: "!help me"},
+			}, nil),
+			fpChat.EXPECT().History(t.Context(), chat.ChatID("100")).Times(1).Return([]chat.Message{
+				{ID: 1, AuthorID: 25, Author: "buyer", Text: "hi"},
+				{ID: 4, AuthorID: 1, Author: "seller", Text: "Commands: !help"},
+				{ID: 5, AuthorID: 25, Author: "buyer", Text: "!HELP"},
+				{ID: 6, AuthorID: 25, Author: "buyer", Text: "where is my order?"},
+			}, nil),
+		)
+
+		fpOrders.EXPECT().Sales(t.Context()).Times(2).Return([]orders.Order{
+			{ID: "OTHER", BuyerID: 30},
+			{ID: "ABCD", BuyerID: 25},
+		}, nil)
+
+		gomock.InOrder(
+			fpChat.EXPECT().Send(t.Context(), chat.ChatID("100"), "Hello, buyer! I am seller").Times(1).Return(nil),
+			fpChat.EXPECT().Send(t.Context(), chat.ChatID("100"), "Commands: !help").Times(1).Return(nil),
+			fpChat.EXPECT().Send(t.Context(), chat.ChatID("100"), "Order #ABCD").Times(1).Return(nil),
+		)
+
+		for range 3 {
+			if err := r.Poll(t.Context()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	t.Run("time window", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+
+		now := time.Now()
+		r, err := responder.New(fp, fpChat, nil,
+			responder.Rule{
+				Name:  "outside",
+				From:  now.Add(2 * time.Hour).Format("15:04"),
+				To:    now.Add(3 * time.Hour).Format("15:04"),
+				Reply: "outside",
+			},
+			responder.Rule{
+				Name:  "inside",
+				From:  now.Add(-time.Hour).Format("15:04"),
+				To:    now.Add(time.Hour).Format("15:04"),
+				Reply: "inside",
+			},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fp.EXPECT().UserID().AnyTimes().Return(int64(1))
+		fp.EXPECT().Username().AnyTimes().Return("seller")
+		fp.EXPECT().Balance().AnyTimes().Return(funpay.Money{})
+		fp.EXPECT().Locale().AnyTimes().Return(funpay.LocaleRU)
+
+		gomock.InOrder(
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return(nil, nil),
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return([]chat.Contact{{ID: "flood-ru", LastMessageID: 1}}, nil),
+		)
+		fpChat.EXPECT().History(t.Context(), chat.ChatID("flood-ru")).Times(1).Return([]chat.Message{
+			{ID: 1, AuthorID: 25, Text: "hello"},
+		}, nil)
+		fpChat.EXPECT().Send(t.Context(), chat.ChatID("flood-ru"), "inside").Times(1).Return(nil)
+
+		for range 2 {
+			if err := r.Poll(t.Context()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+	t.Run("localized templates", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+
+		r, err := responder.New(fp, fpChat, nil, responder.Rule{Name: "greeting", Template: "greeting"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		set := templates.New()
+		if err := set.Add("greeting", funpay.LocaleRU, "Привет, {{.Buyer.Username}}!"); err != nil {
+			t.Fatal(err)
+		}
+		if err := set.Add("greeting", funpay.LocaleEN, "Hello, {{.Buyer.Username}}!"); err != nil {
+			t.Fatal(err)
+		}
+		r.SetTemplates(set)
+
+		fp.EXPECT().UserID().AnyTimes().Return(int64(1))
+		fp.EXPECT().Username().AnyTimes().Return("seller")
+		fp.EXPECT().Balance().AnyTimes().Return(funpay.Money{})
+		fp.EXPECT().Locale().AnyTimes().Return(funpay.LocaleRU)
+
+		gomock.InOrder(
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return(nil, nil),
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return([]chat.Contact{
+				{ID: "100", LastMessageID: 1},
+				{ID: "101", LastMessageID: 2},
+			}, nil),
+		)
+		fpChat.EXPECT().History(t.Context(), chat.ChatID("100")).Times(1).Return([]chat.Message{
+			{ID: 1, AuthorID: 25, Author: "buyer", Text: "hello"},
+		}, nil)
+		fpChat.EXPECT().History(t.Context(), chat.ChatID("101")).Times(1).Return([]chat.Message{
+			{ID: 2, AuthorID: 26, Author: "покупатель", Text: "привет"},
+		}, nil)
+		fpChat.EXPECT().Send(t.Context(), chat.ChatID("100"), "Hello, buyer!").Times(1).Return(nil)
+		fpChat.EXPECT().Send(t.Context(), chat.ChatID("101"), "Привет, покупатель!").Times(1).Return(nil)
+
+		for range 2 {
+			if err := r.Poll(t.Context()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	t.Run("skips message when reply fails to render", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+
+		r, err := responder.New(fp, fpChat, nil, responder.Rule{Name: "greeting", Template: "missing"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fp.EXPECT().UserID().AnyTimes().Return(int64(1))
+		fp.EXPECT().Username().AnyTimes().Return("seller")
+		fp.EXPECT().Balance().AnyTimes().Return(funpay.Money{})
+		fp.EXPECT().Locale().AnyTimes().Return(funpay.LocaleRU)
+
+		gomock.InOrder(
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return(nil, nil),
+			fpChat.EXPECT().Chats(t.Context()).Times(2).Return([]chat.Contact{{ID: "100", LastMessageID: 1}}, nil),
+		)
+		fpChat.EXPECT().History(t.Context(), chat.ChatID("100")).Times(1).Return([]chat.Message{
+			{ID: 1, AuthorID: 25, Text: "hello"},
+		}, nil)
+
+		if err := r.Poll(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := r.Poll(t.Context()); !errors.Is(err, templates.ErrTemplateNotFound) {
+			t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+		}
+
+		if err := r.Poll(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("retries failed reply", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpChat := mocks.NewMockChat(ctrl)
+		fpOrders := mocks.NewMockOrders(ctrl)
+
+		r, err := responder.New(fp, fpChat, fpOrders,
+			responder.Rule{Pattern: "a", Reply: "reply a", Cooldown: responder.Duration(time.Hour)},
+			responder.Rule{Pattern: "b", Reply: "reply b", Cooldown: responder.Duration(time.Hour)},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		fp.EXPECT().UserID().AnyTimes().Return(int64(1))
+		fp.EXPECT().Username().AnyTimes().Return("seller")
+		fp.EXPECT().Balance().AnyTimes().Return(funpay.Money{})
+		fp.EXPECT().Locale().AnyTimes().Return(funpay.LocaleRU)
+
+		errSales := errors.New("sales")
+		gomock.InOrder(
+			fpOrders.EXPECT().Sales(t.Context()).Times(1).Return(nil, errSales),
+			fpOrders.EXPECT().Sales(t.Context()).Times(1).Return(nil, nil),
+		)
+
+		gomock.InOrder(
+			fpChat.EXPECT().Chats(t.Context()).Times(1).Return(nil, nil),
+			fpChat.EXPECT().Chats(t.Context()).Times(3).Return([]chat.Contact{{ID: "100", LastMessageID: 2}}, nil),
+		)
+		fpChat.EXPECT().History(t.Context(), chat.ChatID("100")).Times(2).Return([]chat.Message{
+			{ID: 1, AuthorID: 25, Text: "a"},
+			{ID: 2, AuthorID: 25, Text: "b"},
+		}, nil)
+
+		gomock.InOrder(
+			fpChat.EXPECT().Send(t.Context(), chat.ChatID("100"), "reply a").Times(1).Return(chat.ErrSendFailed),
+			fpChat.EXPECT().Send(t.Context(), chat.ChatID("100"), "reply a").Times(1).Return(nil),
+			fpChat.EXPECT().Send(t.Context(), chat.ChatID("100"), "reply b").Times(1).Return(nil),
+		)
+
+		if err := r.Poll(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = r.Poll(t.Context())
+		if !errors.Is(err, chat.ErrSendFailed) || !errors.Is(err, errSales) {
+			t.Fatalf("expected send and sales errors, got %v", err)
+		}
+
+		for range 2 {
+			if err := r.Poll(t.Context()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
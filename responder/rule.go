@@ -0,0 +1,195 @@
+package responder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kostromin59/funpay/chat"
+)
+
+var (
+	// ErrInvalidRule indicates that [Rule] has invalid pattern, time window or reply template, or has no reply.
+	ErrInvalidRule = errors.New("invalid rule")
+)
+
+// Duration is [time.Duration] which is decoded from JSON strings like "30s" or "5m".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Rule describes when and what to reply. Empty conditions match every message.
+//
+// Reply is a [text/template] executed with [templates.Data]. If Template is set, reply is rendered
+// from [templates.Set] (see [Responder.SetTemplates]) in the language of the message instead.
+type Rule struct {
+	Name string `json:"name"`
+
+	// Pattern is a regular expression matched against message text.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Command matches messages whose first word equals it case-insensitively (e.g. "!help").
+	Command string `json:"command,omitempty"`
+
+	// FirstMessage matches only messages of chats where account has not written yet.
+	FirstMessage bool `json:"first_message,omitempty"`
+
+	// Senders matches messages from provided user IDs only.
+	Senders []int64 `json:"senders,omitempty"`
+
+	// ChatType matches chats of provided type only.
+	ChatType chat.ChatType `json:"chat_type,omitempty"`
+
+	// From and To limit rule to time window in format 15:04. Window may cross midnight (22:00 - 08:00).
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+
+	Reply    string `json:"reply,omitempty"`
+	Template string `json:"template,omitempty"`
+
+	// Cooldown is a minimal interval between replies of this rule in the same chat.
+	Cooldown Duration `json:"cooldown,omitempty"`
+}
+
+// LoadRules reads JSON array of rules from file.
+func LoadRules(path string) ([]Rule, error) {
+	const op = "LoadRules"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
+
+// compiledRule is [Rule] with parsed pattern, time window and reply.
+type compiledRule struct {
+	Rule
+
+	pattern  *regexp.Regexp
+	from, to time.Duration
+	window   bool
+	reply    *template.Template
+
+	// index is position of the rule, it identifies cooldowns because name is optional.
+	index int
+}
+
+func compileRule(rule Rule) (compiledRule, error) {
+	compiled := compiledRule{Rule: rule}
+
+	if rule.Reply == "" && rule.Template == "" {
+		return compiledRule{}, fmt.Errorf("%w %q: empty reply", ErrInvalidRule, rule.Name)
+	}
+
+	if rule.Pattern != "" {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return compiledRule{}, fmt.Errorf("%w %q: %w", ErrInvalidRule, rule.Name, err)
+		}
+
+		compiled.pattern = pattern
+	}
+
+	if rule.From != "" || rule.To != "" {
+		from, err := parseClock(rule.From)
+		if err != nil {
+			return compiledRule{}, fmt.Errorf("%w %q: %w", ErrInvalidRule, rule.Name, err)
+		}
+
+		to, err := parseClock(rule.To)
+		if err != nil {
+			return compiledRule{}, fmt.Errorf("%w %q: %w", ErrInvalidRule, rule.Name, err)
+		}
+
+		compiled.from, compiled.to, compiled.window = from, to, true
+	}
+
+	reply, err := template.New(rule.Name).Parse(rule.Reply)
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("%w %q: %w", ErrInvalidRule, rule.Name, err)
+	}
+
+	compiled.reply = reply
+
+	return compiled, nil
+}
+
+// match checks every condition except cooldown.
+func (r compiledRule) match(contact chat.Contact, msg chat.Message, first bool, now time.Time) bool {
+	if r.FirstMessage && !first {
+		return false
+	}
+
+	if r.ChatType != "" && r.ChatType != contact.ID.Type() {
+		return false
+	}
+
+	if len(r.Senders) != 0 && !slices.Contains(r.Senders, msg.AuthorID) {
+		return false
+	}
+
+	if r.Command != "" {
+		fields := strings.Fields(msg.Text)
+		if len(fields) == 0 || !strings.EqualFold(fields[0], r.Command) {
+			return false
+		}
+	}
+
+	if r.pattern != nil && !r.pattern.MatchString(msg.Text) {
+		return false
+	}
+
+	if r.window {
+		clock := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+		if r.from <= r.to && (clock < r.from || clock >= r.to) {
+			return false
+		}
+
+		if r.from > r.to && clock < r.from && clock >= r.to {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseClock parses time of day in format 15:04.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
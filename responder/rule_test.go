@@ -0,0 +1,94 @@
+package responder_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kostromin59/funpay/chat"
+	"github.com/kostromin59/funpay/mocks"
+	"github.com/kostromin59/funpay/responder"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoadRules(t *testing.T) {
+	t.Parallel()
+	t.Run("successful load", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "rules.json")
+		err := os.WriteFile(path, []byte(`[
+			{"name": "help", "command": "!help", "reply": "Commands: !help", "cooldown": "1m"},
+			{"name": "night", "pattern": "(?i)hello", "chat_type": "private", "from": "22:00", "to": "08:00", "reply": "Sleeping"}
+		]`), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rules, err := responder.LoadRules(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []responder.Rule{
+			{Name: "help", Command: "!help", Reply: "Commands: !help", Cooldown: responder.Duration(time.Minute)},
+			{Name: "night", Pattern: "(?i)hello", ChatType: chat.ChatTypePrivate, From: "22:00", To: "08:00", Reply: "Sleeping"},
+		}
+
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("expected %+v, got %+v", expected, rules)
+		}
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "rules.json")
+		if err := os.WriteFile(path, []byte(`[{"name": "help", "cooldown": "forever"}]`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := responder.LoadRules(path); err == nil {
+			t.Error("expected error for invalid duration")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := responder.LoadRules(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+			t.Error("expected error for missing file")
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule responder.Rule
+	}{
+		{name: "invalid pattern", rule: responder.Rule{Name: "a", Pattern: "(", Reply: "hi"}},
+		{name: "invalid time", rule: responder.Rule{Name: "a", From: "25:00", To: "10:00", Reply: "hi"}},
+		{name: "empty reply", rule: responder.Rule{Name: "a"}},
+		{name: "invalid template", rule: responder.Rule{Name: "a", Reply: "{{.Message"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			_, err := responder.New(mocks.NewMockFunpay(ctrl), mocks.NewMockChat(ctrl), nil, tt.rule)
+			if !errors.Is(err, responder.ErrInvalidRule) {
+				t.Fatalf("expected ErrInvalidRule, got %v", err)
+			}
+		})
+	}
+}
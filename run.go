@@ -0,0 +1,40 @@
+package funpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidInterval indicates that interval passed to [RunEvery] is not positive.
+var ErrInvalidInterval = errors.New("invalid interval")
+
+// RunEvery calls fn immediately and then every interval until context is done. Errors of fn are passed
+// to onError if it is not nil, the loop is not stopped by them. A call of fn is never interrupted by the ticker:
+// if fn takes longer than interval, the next call starts right after it.
+//
+// Returns [ErrInvalidInterval] without calling fn if interval is not positive, otherwise blocks until context is done
+// and returns nil.
+func RunEvery(ctx context.Context, interval time.Duration, fn func(ctx context.Context) error, onError func(err error)) error {
+	const op = "funpay.RunEvery"
+
+	if interval <= 0 {
+		return fmt.Errorf("%s: %w (%s)", op, ErrInvalidInterval, interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package funpay_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kostromin59/funpay"
+)
+
+func TestRunEvery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("calls until context is done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		errTest := errors.New("test")
+
+		var calls, reported int
+		fn := func(ctx context.Context) error {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+
+			return errTest
+		}
+
+		onError := func(err error) {
+			if !errors.Is(err, errTest) {
+				t.Errorf("expected errTest, got %v", err)
+			}
+
+			reported++
+		}
+
+		if err := funpay.RunEvery(ctx, time.Millisecond, fn, onError); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if calls != 3 || reported != 3 {
+			t.Errorf("expected 3 calls and 3 errors, got %d and %d", calls, reported)
+		}
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		t.Parallel()
+
+		fn := func(ctx context.Context) error {
+			t.Error("fn must not be called")
+			return nil
+		}
+
+		for _, interval := range []time.Duration{0, -time.Second} {
+			if err := funpay.RunEvery(t.Context(), interval, fn, nil); !errors.Is(err, funpay.ErrInvalidInterval) {
+				t.Errorf("%s: expected ErrInvalidInterval, got %v", interval, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+)
+
+var (
+	// ErrFormNotFound indicates that settings page does not contain settings form.
+	ErrFormNotFound = errors.New("settings form not found")
+)
+
+type (
+	// FieldKey represents key type for [Fields].
+	FieldKey string
+	// Fields represents type contains fields of the settings form.
+	Fields map[FieldKey]Field
+)
+
+// Field represents type contains description of the field of the settings form.
+type Field struct {
+	Value    string   `json:"value"`
+	Variants []string `json:"variants"`
+
+	// Checkbox reports whether the field is checkbox. Value of enabled checkbox is "on", disabled is empty.
+	Checkbox bool `json:"checkbox"`
+}
+
+// settingsFormSelector matches the settings form by its action: relative or absolute, with or without locale prefix.
+// Other forms of the page (search, chat) are not matched.
+const settingsFormSelector = `form[action$="/account/settings"], form[action$="/account/settings/"]`
+
+const (
+	// FieldAway is the key of the checkbox enabling away mode (offers are hidden while the seller is away).
+	FieldAway FieldKey = "away"
+)
+
+// Enabled reports whether the checkbox is enabled.
+func (f Fields) Enabled(key FieldKey) bool {
+	return f[key].Value != ""
+}
+
+// SetEnabled enables or disables the checkbox.
+func (f Fields) SetEnabled(key FieldKey, enabled bool) {
+	field := f[key]
+	field.Checkbox = true
+	field.Value = ""
+	if enabled {
+		field.Value = "on"
+	}
+
+	f[key] = field
+}
+
+// SetValue sets value of the text field, textarea (e.g. auto-reply text) or select.
+func (f Fields) SetValue(key FieldKey, value string) {
+	field := f[key]
+	field.Value = value
+	f[key] = field
+}
+
+//go:generate go tool mockgen -destination ../mocks/settings.go -package mocks . Settings
+type Settings interface {
+	// Get loads fields of the settings form (/account/settings): notifications, away mode, auto-replies, etc.
+	// Returns [ErrFormNotFound] if the page does not contain the form.
+	Get(ctx context.Context) (Fields, error)
+
+	// Save submits fields to the settings form. Use [Settings.Get] to get fields.
+	// Disabled checkboxes are not sent like in a browser.
+	Save(ctx context.Context, fields Fields) error
+
+	// SetAway loads settings, switches [FieldAway] and saves them.
+	SetAway(ctx context.Context, away bool) error
+}
+
+type SettingsClient struct {
+	fp funpay.Funpay
+}
+
+func New(fp funpay.Funpay) Settings {
+	return &SettingsClient{
+		fp: fp,
+	}
+}
+
+// logger returns logger of the client (see [funpay.FunpayRequester.Logger]).
+func (s *SettingsClient) logger() *slog.Logger {
+	return s.fp.Logger().With(slog.String("module", "settings"))
+}
+
+func (s *SettingsClient) Get(ctx context.Context) (Fields, error) {
+	const op = "SettingsClient.Get"
+
+	reqURL, err := s.settingsURL()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := s.fp.RequestHTML(ctx, reqURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	form := doc.Find(settingsFormSelector).First()
+	if form.Length() == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrFormNotFound)
+	}
+
+	return extractFields(form), nil
+}
+
+func (s *SettingsClient) Save(ctx context.Context, fields Fields) error {
+	const op = "SettingsClient.Save"
+
+	reqURL, err := s.settingsURL()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	body := url.Values{}
+	for name, field := range fields {
+		if field.Checkbox && field.Value == "" {
+			continue
+		}
+
+		body.Set(string(name), field.Value)
+	}
+
+	body.Set(funpay.FormCSRFToken, s.fp.CSRFToken())
+
+	resp, err := s.fp.Request(ctx, reqURL.String(),
+		funpay.RequestWithMethod(http.MethodPost),
+		funpay.RequestWithBody(strings.NewReader(body.Encode())),
+		funpay.RequestWithHeaders(funpay.RequestPostHeaders),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	resp.Body.Close()
+
+	s.logger().LogAttrs(ctx, slog.LevelInfo, "settings saved", slog.Int("fields", len(fields)))
+
+	return nil
+}
+
+func (s *SettingsClient) SetAway(ctx context.Context, away bool) error {
+	const op = "SettingsClient.SetAway"
+
+	fields, err := s.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	fields.SetEnabled(FieldAway, away)
+
+	if err := s.Save(ctx, fields); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *SettingsClient) settingsURL() (*url.URL, error) {
+	return funpay.URL("account", "settings").WithBaseURL(s.fp.BaseURL()).Parse()
+}
+
+func extractFields(form *goquery.Selection) Fields {
+	fields := make(Fields)
+	for name, field := range funpay.ExtractForm(form) {
+		fields[FieldKey(name)] = Field(field)
+	}
+
+	return fields
+}
@@ -0,0 +1,228 @@
+package settings_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/mocks"
+	"github.com/kostromin59/funpay/settings"
+	"go.uber.org/mock/gomock"
+)
+
+const settingsPage = `<html>
+	<body data-app-data='{"userId":1,"csrf-token":"csrf","locale":"ru"}'>
+		<form class="form-search" method="get" action="/lots/search">
+			<input type="text" name="query">
+		</form>
+		<form method="post" action="/account/settings">
+			<input type="hidden" name="csrf_token" value="csrf">
+			<input type="checkbox" name="away">
+			<input type="checkbox" name="notify_email" checked>
+			<input type="radio" name="notify_sound" value="0">
+			<input type="radio" name="notify_sound" value="1" checked>
+			<textarea name="auto_reply">Привет!</textarea>
+			<select name="timezone">
+				<option value="Europe/Moscow" selected>Москва</option>
+				<option value="Asia/Yekaterinburg">Екатеринбург</option>
+			</select>
+		</form>
+	</body>
+</html>`
+
+func TestSettings_Get(t *testing.T) {
+	t.Parallel()
+	t.Run("successful settings retrieval", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpSettings := settings.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(settingsPage))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/settings").Times(1).Return(doc, nil)
+
+		fields, err := fpSettings.Get(t.Context())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := settings.Fields{
+			settings.FieldAway: {Variants: []string{"on"}, Checkbox: true},
+			"notify_email":     {Value: "on", Variants: []string{"on"}, Checkbox: true},
+			"notify_sound":     {Value: "1", Variants: []string{"0", "1"}},
+			"auto_reply":       {Value: "Привет!"},
+			"timezone":         {Value: "Europe/Moscow", Variants: []string{"Europe/Moscow", "Asia/Yekaterinburg"}},
+		}
+
+		if !reflect.DeepEqual(fields, expected) {
+			t.Errorf("expected %+v, got %+v", expected, fields)
+		}
+
+		if fields.Enabled(settings.FieldAway) || !fields.Enabled("notify_email") {
+			t.Errorf("unexpected checkboxes state: %+v", fields)
+		}
+	})
+
+	t.Run("localized form action", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpSettings := settings.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+			<body>
+				<form action="/en/lots/search"><input type="text" name="query"></form>
+				<form method="post" action="https://funpay.com/en/account/settings/">
+					<input type="checkbox" name="away" checked>
+				</form>
+			</body>
+		</html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/settings").Times(1).Return(doc, nil)
+
+		fields, err := fpSettings.Get(t.Context())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(fields) != 1 || !fields.Enabled(settings.FieldAway) {
+			t.Errorf("expected enabled away mode only, got %+v", fields)
+		}
+	})
+
+	t.Run("form not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpSettings := settings.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+			<body>
+				<form action="/lots/search"><input type="text" name="query"></form>
+			</body>
+		</html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/settings").Times(1).Return(doc, nil)
+
+		_, err = fpSettings.Get(t.Context())
+		if !errors.Is(err, settings.ErrFormNotFound) {
+			t.Errorf("expected ErrFormNotFound, got %v", err)
+		}
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpSettings := settings.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/account/settings").Times(1).Return(nil, funpay.ErrAccountUnauthorized)
+
+		_, err := fpSettings.Get(t.Context())
+		if !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Errorf("expected ErrAccountUnauthorized, got %v", err)
+		}
+	})
+}
+
+func TestSettings_Save(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fp := mocks.NewMockFunpay(ctrl)
+	fpSettings := settings.New(fp)
+
+	fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+	fp.EXPECT().CSRFToken().Times(1).Return("csrf")
+	fp.EXPECT().Request(t.Context(), "https://funpay.com/account/settings", gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).Return(nil, funpay.ErrTooManyRequests)
+
+	err := fpSettings.Save(t.Context(), settings.Fields{settings.FieldAway: {Value: "on", Checkbox: true}})
+	if !errors.Is(err, funpay.ErrTooManyRequests) {
+		t.Errorf("expected ErrTooManyRequests, got %v", err)
+	}
+}
+
+func TestSettings_SetAway(t *testing.T) {
+	t.Parallel()
+
+	var (
+		form url.Values
+		mu   sync.Mutex
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/account/settings" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			form, _ = url.ParseQuery(string(body))
+			mu.Unlock()
+			return
+		}
+
+		io.WriteString(w, settingsPage)
+	}))
+	defer ts.Close()
+
+	fp := funpay.New("test_key", "test_agent")
+	fp.SetBaseURL(ts.URL)
+
+	if err := settings.New(fp).SetAway(t.Context(), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := url.Values{
+		"away":         {"on"},
+		"notify_email": {"on"},
+		"notify_sound": {"1"},
+		"auto_reply":   {"Привет!"},
+		"timezone":     {"Europe/Moscow"},
+		"csrf_token":   {"csrf"},
+	}
+	if !reflect.DeepEqual(form, expected) {
+		t.Errorf("expected form %v, got %v", expected, form)
+	}
+}
@@ -0,0 +1,272 @@
+package funpay_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+// socksServer is a minimal in-process SOCKS5 server (RFC 1928, RFC 1929) supporting CONNECT only.
+type socksServer struct {
+	listener net.Listener
+	username string
+	password string
+
+	mu        sync.Mutex
+	addresses []string
+}
+
+func newSocksServer(t *testing.T, username, password string) *socksServer {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &socksServer{listener: l, username: username, password: password}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *socksServer) url(username, password string) *url.URL {
+	u := &url.URL{Scheme: "socks5", Host: s.listener.Addr().String()}
+	if username != "" {
+		u.User = url.UserPassword(username, password)
+	}
+
+	return u
+}
+
+// requested returns addresses requested by clients as they were sent (domain or IP).
+func (s *socksServer) requested() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.addresses...)
+}
+
+func (s *socksServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	// Greeting: version, count of methods, methods.
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil || header[0] != 5 {
+		return
+	}
+
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return
+	}
+
+	method := byte(0x00)
+	if s.username != "" {
+		method = 0x02
+	}
+
+	if _, err := conn.Write([]byte{5, method}); err != nil {
+		return
+	}
+
+	if method == 0x02 && !s.authenticate(conn) {
+		return
+	}
+
+	// Request: version, command, reserved, address type, address, port.
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil || request[1] != 1 {
+		return
+	}
+
+	var host string
+	switch request[3] {
+	case 1:
+		ip := make([]byte, 4)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return
+		}
+		host = net.IP(ip).String()
+	case 3:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return
+		}
+		domain := make([]byte, size[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return
+		}
+		host = string(domain)
+	case 4:
+		ip := make([]byte, 16)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return
+		}
+		host = net.IP(ip).String()
+	default:
+		return
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return
+	}
+
+	addr := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+
+	s.mu.Lock()
+	s.addresses = append(s.addresses, addr)
+	s.mu.Unlock()
+
+	target, err := net.Dial("tcp", addr)
+	if err != nil {
+		conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer target.Close()
+
+	if _, err := conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}); err != nil {
+		return
+	}
+
+	go io.Copy(target, conn)
+	io.Copy(conn, target)
+}
+
+// authenticate handles username/password subnegotiation.
+func (s *socksServer) authenticate(conn net.Conn) bool {
+	version := make([]byte, 2)
+	if _, err := io.ReadFull(conn, version); err != nil {
+		return false
+	}
+
+	username := make([]byte, version[1])
+	if _, err := io.ReadFull(conn, username); err != nil {
+		return false
+	}
+
+	size := make([]byte, 1)
+	if _, err := io.ReadFull(conn, size); err != nil {
+		return false
+	}
+
+	password := make([]byte, size[0])
+	if _, err := io.ReadFull(conn, password); err != nil {
+		return false
+	}
+
+	if string(username) != s.username || string(password) != s.password {
+		conn.Write([]byte{1, 1})
+		return false
+	}
+
+	_, err := conn.Write([]byte{1, 0})
+
+	return err == nil
+}
+
+func TestFunpay_RequestSocks5(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	t.Cleanup(ts.Close)
+
+	tsURL, _ := url.Parse(ts.URL)
+
+	t.Run("without authentication", func(t *testing.T) {
+		t.Parallel()
+
+		socks := newSocksServer(t, "", "")
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.SetProxy(socks.url("", ""))
+
+		resp, err := fp.Request(t.Context(), ts.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "ok" {
+			t.Errorf("unexpected body: %q", body)
+		}
+
+		if requested := socks.requested(); len(requested) != 1 || requested[0] != tsURL.Host {
+			t.Errorf("unexpected requested addresses: %v", requested)
+		}
+	})
+
+	t.Run("with authentication", func(t *testing.T) {
+		t.Parallel()
+
+		socks := newSocksServer(t, "user", "pass")
+
+		fp := funpay.New("test_key", "test_agent")
+		resp, err := fp.Request(t.Context(), ts.URL, funpay.RequestWithProxy(socks.url("user", "pass")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+
+		if _, err := fp.Request(t.Context(), ts.URL, funpay.RequestWithProxy(socks.url("user", "wrong"))); err == nil {
+			t.Error("expected authentication error")
+		}
+	})
+
+	t.Run("socks5h resolves host on proxy", func(t *testing.T) {
+		t.Parallel()
+
+		socks := newSocksServer(t, "user", "pass")
+		proxy := socks.url("user", "pass")
+		proxy.Scheme = "socks5h"
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.SetProxy(proxy)
+
+		resp, err := fp.Request(t.Context(), "http://localhost:"+tsURL.Port())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+
+		if requested := socks.requested(); len(requested) != 1 || requested[0] != "localhost:"+tsURL.Port() {
+			t.Errorf("expected domain to be sent to proxy, got %v", requested)
+		}
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		t.Parallel()
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.SetProxy(&url.URL{Scheme: "socks4", Host: "127.0.0.1:1080"})
+
+		if _, err := fp.Request(t.Context(), ts.URL); !errors.Is(err, funpay.ErrUnsupportedProxy) {
+			t.Errorf("expected ErrUnsupportedProxy, got %v", err)
+		}
+	})
+}
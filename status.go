@@ -0,0 +1,20 @@
+package funpay
+
+import "time"
+
+// Status represents the result of the last [FunpayUpdater.Update] or [FunpayUpdater.UpdateLocale] call.
+type Status struct {
+	// LastUpdate is the time of the last update attempt. Zero if the client has never been updated.
+	LastUpdate time.Time
+
+	// LastSuccess is the time of the last successful update.
+	LastSuccess time.Time
+
+	// Err is the error of the last update attempt. Nil if it succeeded.
+	Err error
+}
+
+// OK reports whether the last update succeeded.
+func (s Status) OK() bool {
+	return !s.LastUpdate.IsZero() && s.Err == nil
+}
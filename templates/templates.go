@@ -0,0 +1,205 @@
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/chat"
+	"github.com/kostromin59/funpay/lots"
+	"github.com/kostromin59/funpay/orders"
+)
+
+var (
+	// ErrTemplateNotFound indicates that there is no template with provided name in any locale.
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+// Account represents account variables of [Data].
+type Account struct {
+	ID       int64
+	Username string
+	Balance  funpay.Money
+	Locale   funpay.Locale
+}
+
+// NewAccount collects account variables from loaded account info (see [funpay.FunpayUpdater.Update]).
+func NewAccount(fp funpay.FunpayUser) Account {
+	return Account{
+		ID:       fp.UserID(),
+		Username: fp.Username(),
+		Balance:  fp.Balance(),
+		Locale:   fp.Locale(),
+	}
+}
+
+// Buyer represents buyer variables of [Data].
+type Buyer struct {
+	ID       int64
+	Username string
+}
+
+// Lot represents lot variables of [Data].
+type Lot struct {
+	NodeID  lots.NodeID
+	OfferID lots.OfferID
+	Fields  lots.Fields
+}
+
+// Data is passed into templates. Nil fields are unknown in the current context, use {{with}} to check them.
+type Data struct {
+	Account Account
+	Buyer   Buyer
+	Order   *orders.Order
+	Lot     *Lot
+	Message *chat.Message
+	Chat    *chat.Contact
+}
+
+// Set holds named templates with variants per [funpay.Locale].
+//
+// [Set.Render] picks variant by requested locale, then by fallback locales in order,
+// then any existing variant.
+type Set struct {
+	templates map[string]map[funpay.Locale]*template.Template
+	fallback  []funpay.Locale
+	mu        sync.RWMutex
+}
+
+// New creates empty [Set]. Defaults fallback to [funpay.LocaleRU], [funpay.LocaleEN] if nothing is provided.
+func New(fallback ...funpay.Locale) *Set {
+	if len(fallback) == 0 {
+		fallback = []funpay.Locale{funpay.LocaleRU, funpay.LocaleEN}
+	}
+
+	return &Set{
+		templates: make(map[string]map[funpay.Locale]*template.Template),
+		fallback:  fallback,
+	}
+}
+
+// Load reads JSON object of templates from file: {"name": {"ru": "...", "en": "..."}}.
+func Load(path string, fallback ...funpay.Locale) (*Set, error) {
+	const op = "templates.Load"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var raw map[string]map[funpay.Locale]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s := New(fallback...)
+	for name, variants := range raw {
+		for locale, text := range variants {
+			if err := s.Add(name, locale, text); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// Add parses text as [text/template] and saves it as variant of the template for provided locale.
+func (s *Set) Add(name string, locale funpay.Locale, text string) error {
+	const op = "Set.Add"
+
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.templates[name]; !ok {
+		s.templates[name] = make(map[funpay.Locale]*template.Template)
+	}
+
+	s.templates[name][locale] = tmpl
+
+	return nil
+}
+
+// Has reports whether template exists in any locale.
+func (s *Set) Has(name string) bool {
+	s.mu.RLock()
+	_, ok := s.templates[name]
+	s.mu.RUnlock()
+
+	return ok
+}
+
+// Render executes template variant for locale with provided data.
+// Returns [ErrTemplateNotFound] if template does not exist.
+func (s *Set) Render(name string, locale funpay.Locale, data Data) (string, error) {
+	const op = "Set.Render"
+
+	tmpl, ok := s.variant(name, locale)
+	if !ok {
+		return "", fmt.Errorf("%s: %w (%s)", op, ErrTemplateNotFound, name)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return b.String(), nil
+}
+
+func (s *Set) variant(name string, locale funpay.Locale) (*template.Template, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	variants, ok := s.templates[name]
+	if !ok || len(variants) == 0 {
+		return nil, false
+	}
+
+	for _, l := range append([]funpay.Locale{locale}, s.fallback...) {
+		if tmpl, ok := variants[l]; ok {
+			return tmpl, true
+		}
+	}
+
+	locales := make([]funpay.Locale, 0, len(variants))
+	for l := range variants {
+		locales = append(locales, l)
+	}
+	slices.Sort(locales)
+
+	return variants[locales[0]], true
+}
+
+// DetectLocale guesses locale of the text: [funpay.LocaleRU] if it contains cyrillic letters,
+// [funpay.LocaleEN] if it contains latin letters only, fallback otherwise.
+func DetectLocale(text string, fallback funpay.Locale) funpay.Locale {
+	var latin bool
+	for _, r := range text {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return funpay.LocaleRU
+		}
+
+		if unicode.Is(unicode.Latin, r) {
+			latin = true
+		}
+	}
+
+	if latin {
+		return funpay.LocaleEN
+	}
+
+	return fallback
+}
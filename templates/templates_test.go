@@ -0,0 +1,146 @@
+package templates_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/mocks"
+	"github.com/kostromin59/funpay/orders"
+	"github.com/kostromin59/funpay/templates"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSet_Render(t *testing.T) {
+	t.Parallel()
+
+	set := templates.New(funpay.LocaleEN)
+	if err := set.Add("thanks", funpay.LocaleRU, "Спасибо за заказ #{{.Order.ID}}, {{.Buyer.Username}}!"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Add("thanks", funpay.LocaleEN, "Thanks for order #{{.Order.ID}}, {{.Buyer.Username}}!"); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Add("balance", funpay.LocaleRU, `{{.Account.Username}}: {{.Account.Balance.Format "ru"}}`); err != nil {
+		t.Fatal(err)
+	}
+
+	data := templates.Data{
+		Account: templates.Account{Username: "seller", Balance: funpay.NewMoney(1234, 50, funpay.CurrencyRUB)},
+		Buyer:   templates.Buyer{ID: 25, Username: "buyer"},
+		Order:   &orders.Order{ID: "ABCD"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		locale   funpay.Locale
+		expected string
+	}{
+		{name: "requested locale", template: "thanks", locale: funpay.LocaleRU, expected: "Спасибо за заказ #ABCD, buyer!"},
+		{name: "fallback locale", template: "thanks", locale: "uk", expected: "Thanks for order #ABCD, buyer!"},
+		{name: "any locale", template: "balance", locale: funpay.LocaleEN, expected: "seller: 1 234,5 ₽"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			text, err := set.Render(tt.template, tt.locale, data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if text != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, text)
+			}
+		})
+	}
+
+	t.Run("unknown template", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := set.Render("unknown", funpay.LocaleRU, data); !errors.Is(err, templates.ErrTemplateNotFound) {
+			t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	t.Run("successful load", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "templates.json")
+		err := os.WriteFile(path, []byte(`{"greeting": {"ru": "Привет", "en": "Hello"}}`), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		set, err := templates.Load(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		text, err := set.Render("greeting", funpay.LocaleEN, templates.Data{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if text != "Hello" {
+			t.Errorf("expected %q, got %q", "Hello", text)
+		}
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "templates.json")
+		if err := os.WriteFile(path, []byte(`{"greeting": {"ru": "{{.Buyer"}}`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := templates.Load(path); err == nil {
+			t.Error("expected error for invalid template")
+		}
+	})
+}
+
+func TestDetectLocale(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text     string
+		expected funpay.Locale
+	}{
+		{text: "Привет, where is my order?", expected: funpay.LocaleRU},
+		{text: "hello", expected: funpay.LocaleEN},
+		{text: "123 !!!", expected: funpay.LocaleRU},
+	}
+
+	for _, tt := range tests {
+		if locale := templates.DetectLocale(tt.text, funpay.LocaleRU); locale != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.text, tt.expected, locale)
+		}
+	}
+}
+
+func TestNewAccount(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fp := mocks.NewMockFunpay(ctrl)
+	fp.EXPECT().UserID().Times(1).Return(int64(1))
+	fp.EXPECT().Username().Times(1).Return("seller")
+	fp.EXPECT().Balance().Times(1).Return(funpay.NewMoney(100, 0, funpay.CurrencyRUB))
+	fp.EXPECT().Locale().Times(1).Return(funpay.LocaleEN)
+
+	expected := templates.Account{ID: 1, Username: "seller", Balance: funpay.NewMoney(100, 0, funpay.CurrencyRUB), Locale: funpay.LocaleEN}
+	if account := templates.NewAccount(fp); account != expected {
+		t.Errorf("expected %+v, got %+v", expected, account)
+	}
+}
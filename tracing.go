@@ -0,0 +1,35 @@
+package funpay
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Tracer starts spans of Funpay operations (see [FunpayRequester.SetTracer]).
+// Implement it with adapter to your tracing backend, OpenTelemetry adapter is provided by package tracing.
+type Tracer interface {
+	// Start starts span as a child of span from ctx and returns context containing the new span.
+	Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, Span)
+}
+
+// Span represents single operation of the trace.
+type Span interface {
+	// SetAttributes adds attributes to the span (e.g. status code after response).
+	SetAttributes(attrs ...slog.Attr)
+
+	// End finishes the span. Err is nil if operation succeeded.
+	End(err error)
+}
+
+// NoopTracer is [Tracer] which does nothing. It is used if tracer is not set.
+type NoopTracer struct{}
+
+func (NoopTracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...slog.Attr) {}
+
+func (noopSpan) End(err error) {}
@@ -0,0 +1,109 @@
+// Package tracing adapts OpenTelemetry tracer to [funpay.Tracer].
+package tracing
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/kostromin59/funpay"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is [funpay.Tracer] which starts OpenTelemetry spans.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// New creates [Tracer], e.g. New(otel.Tracer("funpay")). Set it with [funpay.FunpayRequester.SetTracer].
+func New(tracer trace.Tracer) *Tracer {
+	return &Tracer{
+		tracer: tracer,
+	}
+}
+
+// Start starts OpenTelemetry span as a child of span from ctx. Attributes are converted by [Attributes].
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, funpay.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(Attributes(attrs...)...))
+	return ctx, Span{span: span}
+}
+
+// Span is [funpay.Span] wrapping OpenTelemetry span.
+type Span struct {
+	span trace.Span
+}
+
+// SetAttributes adds attributes converted by [Attributes] to the span.
+func (s Span) SetAttributes(attrs ...slog.Attr) {
+	s.span.SetAttributes(Attributes(attrs...)...)
+}
+
+// End records err and sets error status if err is not nil, then ends the span.
+func (s Span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+
+	s.span.End()
+}
+
+// Attributes converts slog attributes to OpenTelemetry ones keeping their types: strings, bools, integers
+// and floats are converted as is. Durations are converted to float seconds, times to RFC 3339 strings,
+// uint64 values exceeding int64 and other values to strings. Attributes of groups are flattened with keys
+// prefixed by the group name ("group.key"), attributes without key are skipped.
+func Attributes(attrs ...slog.Attr) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		kvs = appendAttr(kvs, "", attr)
+	}
+
+	return kvs
+}
+
+func appendAttr(kvs []attribute.KeyValue, prefix string, attr slog.Attr) []attribute.KeyValue {
+	key := attr.Key
+	switch {
+	case prefix != "" && key != "":
+		key = prefix + "." + key
+	case prefix != "":
+		key = prefix
+	}
+
+	v := attr.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		for _, a := range v.Group() {
+			kvs = appendAttr(kvs, key, a)
+		}
+
+		return kvs
+	}
+
+	if key == "" {
+		return kvs
+	}
+
+	switch v.Kind() {
+	case slog.KindString:
+		return append(kvs, attribute.String(key, v.String()))
+	case slog.KindBool:
+		return append(kvs, attribute.Bool(key, v.Bool()))
+	case slog.KindInt64:
+		return append(kvs, attribute.Int64(key, v.Int64()))
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return append(kvs, attribute.Int64(key, int64(u)))
+		}
+	case slog.KindFloat64:
+		return append(kvs, attribute.Float64(key, v.Float64()))
+	case slog.KindDuration:
+		return append(kvs, attribute.Float64(key, v.Duration().Seconds()))
+	case slog.KindTime:
+		return append(kvs, attribute.String(key, v.Time().Format(time.RFC3339Nano)))
+	}
+
+	return append(kvs, attribute.String(key, v.String()))
+}
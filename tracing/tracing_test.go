@@ -0,0 +1,163 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTracer(t *testing.T) (*tracing.Tracer, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return tracing.New(provider.Tracer("funpay")), recorder
+}
+
+func TestAttributes(t *testing.T) {
+	t.Parallel()
+
+	attrs := tracing.Attributes(
+		slog.String("op", "Funpay.Request"),
+		slog.Int("status", 200),
+		slog.Int64("user_id", 123),
+		slog.Uint64("small", 1),
+		slog.Uint64("big", math.MaxUint64),
+		slog.Bool("ok", true),
+		slog.Float64("price", 1.5),
+		slog.Duration("latency", 1500*time.Millisecond),
+		slog.Time("at", time.Date(2025, 4, 10, 12, 0, 0, 0, time.UTC)),
+		slog.Group("http", slog.String("method", "GET"), slog.Group("response", slog.Int("size", 10))),
+		slog.Any("locale", funpay.LocaleEN),
+		slog.String("", "skipped"),
+	)
+
+	expected := []attribute.KeyValue{
+		attribute.String("op", "Funpay.Request"),
+		attribute.Int64("status", 200),
+		attribute.Int64("user_id", 123),
+		attribute.Int64("small", 1),
+		attribute.String("big", "18446744073709551615"),
+		attribute.Bool("ok", true),
+		attribute.Float64("price", 1.5),
+		attribute.Float64("latency", 1.5),
+		attribute.String("at", "2025-04-10T12:00:00Z"),
+		attribute.String("http.method", "GET"),
+		attribute.Int64("http.response.size", 10),
+		attribute.String("locale", "en"),
+	}
+
+	if !reflect.DeepEqual(attrs, expected) {
+		t.Errorf("expected %v, got %v", expected, attrs)
+	}
+}
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nested spans", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, recorder := newTracer(t)
+
+		ctx, parent := tracer.Start(t.Context(), "parent", slog.String("op", "parent"))
+		_, child := tracer.Start(ctx, "child")
+		child.SetAttributes(slog.Int("status", 200))
+		child.End(nil)
+		parent.End(nil)
+
+		spans := recorder.Ended()
+		if len(spans) != 2 {
+			t.Fatalf("expected 2 spans, got %d", len(spans))
+		}
+
+		if spans[0].Name() != "child" || spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+			t.Errorf("expected child of parent, got %s with parent %s", spans[0].Name(), spans[0].Parent().SpanID())
+		}
+
+		if expected := []attribute.KeyValue{attribute.Int64("status", 200)}; !reflect.DeepEqual(spans[0].Attributes(), expected) {
+			t.Errorf("expected %v, got %v", expected, spans[0].Attributes())
+		}
+
+		if spans[0].Status().Code != codes.Unset {
+			t.Errorf("expected unset status, got %v", spans[0].Status())
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, recorder := newTracer(t)
+
+		_, span := tracer.Start(t.Context(), "failed")
+		span.End(errors.New("test"))
+
+		spans := recorder.Ended()
+		if len(spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(spans))
+		}
+
+		if status := spans[0].Status(); status.Code != codes.Error || status.Description != "test" {
+			t.Errorf("expected error status, got %v", status)
+		}
+
+		if events := spans[0].Events(); len(events) != 1 || events[0].Name != "exception" {
+			t.Errorf("expected exception event, got %v", events)
+		}
+	})
+
+	t.Run("funpay requests", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+		t.Cleanup(ts.Close)
+
+		tracer, recorder := newTracer(t)
+
+		fp := funpay.New("golden key", "user agent")
+		fp.SetTracer(tracer)
+
+		if _, err := fp.Request(t.Context(), ts.URL); !errors.Is(err, funpay.ErrBadStatusCode) {
+			t.Fatalf("expected ErrBadStatusCode, got %v", err)
+		}
+
+		spans := recorder.Ended()
+		if len(spans) != 1 || spans[0].Name() != "funpay.Request" {
+			t.Fatalf("expected funpay.Request span, got %v", spans)
+		}
+
+		var status attribute.Value
+		for _, attr := range spans[0].Attributes() {
+			if attr.Key == "status" {
+				status = attr.Value
+			}
+		}
+
+		if status.Type() != attribute.INT64 || status.AsInt64() != http.StatusTeapot {
+			t.Errorf("expected int status %d, got %v", http.StatusTeapot, status.Emit())
+		}
+
+		if spans[0].Status().Code != codes.Error {
+			t.Errorf("expected error status, got %v", spans[0].Status())
+		}
+	})
+}
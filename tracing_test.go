@@ -0,0 +1,155 @@
+package funpay_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]slog.Value
+	err    error
+	ended  bool
+}
+
+type recordingTracer struct {
+	spans []*recordedSpan
+	mu    sync.Mutex
+}
+
+type spanKey struct{}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, funpay.Span) {
+	span := &recordedSpan{name: name, attrs: make(map[string]slog.Value)}
+	if parent, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		span.parent = parent.name
+	}
+
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+
+	s := &recordingSpan{tracer: t, span: span}
+	s.SetAttributes(attrs...)
+
+	return context.WithValue(ctx, spanKey{}, span), s
+}
+
+func (t *recordingTracer) span(name string) *recordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, span := range t.spans {
+		if span.name == name {
+			return span
+		}
+	}
+
+	return nil
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	span   *recordedSpan
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...slog.Attr) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	for _, attr := range attrs {
+		s.span.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordingSpan) End(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.span.err = err
+	s.span.ended = true
+}
+
+func TestFunpay_Tracer(t *testing.T) {
+	t.Parallel()
+	t.Run("noop by default", func(t *testing.T) {
+		t.Parallel()
+
+		fp := funpay.New("test_key", "test_agent")
+		if _, ok := fp.Tracer().(funpay.NoopTracer); !ok {
+			t.Fatalf("expected NoopTracer, got %T", fp.Tracer())
+		}
+	})
+
+	t.Run("traces update with nested request", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`<html><body data-app-data='{"userId":1,"csrf-token":"test","locale":"ru"}'></body></html>`))
+		}))
+		defer ts.Close()
+
+		tracer := &recordingTracer{}
+		fp := funpay.New("test_key", "test_agent")
+		fp.SetBaseURL(ts.URL)
+		fp.SetTracer(tracer)
+
+		if err := fp.Update(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		update := tracer.span("funpay.Update")
+		if update == nil || !update.ended || update.err != nil || update.attrs["op"].String() != "FunpayClient.Update" {
+			t.Fatalf("unexpected update span: %+v", update)
+		}
+
+		request := tracer.span("funpay.Request")
+		if request == nil || !request.ended || request.parent != "funpay.Update" {
+			t.Fatalf("unexpected request span: %+v", request)
+		}
+
+		if request.attrs["status"].Int64() != http.StatusOK || request.attrs["method"].String() != http.MethodGet {
+			t.Errorf("unexpected request span attributes: %v", request.attrs)
+		}
+	})
+
+	t.Run("ends span with error", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer ts.Close()
+
+		tracer := &recordingTracer{}
+		fp := funpay.New("golden_secret", "test_agent")
+		fp.SetTracer(tracer)
+
+		_, err := fp.Request(t.Context(), ts.URL+"/?golden_key=golden_secret")
+		if !errors.Is(err, funpay.ErrTooManyRequests) {
+			t.Fatalf("expected ErrTooManyRequests, got %v", err)
+		}
+
+		request := tracer.span("funpay.Request")
+		if request == nil || !errors.Is(request.err, funpay.ErrTooManyRequests) {
+			t.Fatalf("unexpected request span: %+v", request)
+		}
+
+		if request.attrs["status"].Int64() != http.StatusTooManyRequests {
+			t.Errorf("expected status attribute, got %v", request.attrs)
+		}
+
+		if strings.Contains(request.attrs["url"].String(), "golden_secret") {
+			t.Errorf("span contains golden key: %v", request.attrs["url"])
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package funpay
+
+import (
+	"net/url"
+	"strings"
+)
+
+// URLBuilder builds URL of Funpay page (see [URL]).
+type URLBuilder struct {
+	baseURL string
+	locale  Locale
+	path    []string
+	query   url.Values
+}
+
+// URL creates [URLBuilder] for path segments, e.g. URL("users", "123", "/") builds https://funpay.com/users/123/.
+// Trailing "/" segment keeps trailing slash. Base URL defaults to [BaseURL], locale is not set.
+func URL(path ...string) *URLBuilder {
+	return &URLBuilder{
+		baseURL: BaseURL,
+		path:    path,
+		query:   url.Values{},
+	}
+}
+
+// WithBaseURL sets base URL, e.g. [FunpayUpdater.BaseURL].
+func (b *URLBuilder) WithBaseURL(baseURL string) *URLBuilder {
+	b.baseURL = baseURL
+	return b
+}
+
+// WithLocale adds path prefix of the locale (see [Locale.PathPrefix]).
+// Not needed for GET requests of [FunpayRequester.Request]: it adds prefix of the account locale itself.
+func (b *URLBuilder) WithLocale(locale Locale) *URLBuilder {
+	b.locale = locale
+	return b
+}
+
+// WithQuery sets query param.
+func (b *URLBuilder) WithQuery(key, value string) *URLBuilder {
+	b.query.Set(key, value)
+	return b
+}
+
+// Parse returns built URL. Returns error if base URL is invalid.
+func (b *URLBuilder) Parse() (*url.URL, error) {
+	u, err := url.Parse(b.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.path) != 0 {
+		u = u.JoinPath(b.path...)
+	}
+
+	if len(b.query) != 0 {
+		q := u.Query()
+		for key, values := range b.query {
+			q[key] = values
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	return localizeURL(u, b.locale), nil
+}
+
+// String returns built URL. Returns empty string if base URL is invalid.
+func (b *URLBuilder) String() string {
+	u, err := b.Parse()
+	if err != nil {
+		return ""
+	}
+
+	return u.String()
+}
+
+// LinkID returns path segment following the section in the Funpay link, e.g. "2852" for
+// LinkID("https://funpay.com/en/lots/2852/", "lots"). Locale prefix is ignored (see [LocaleByPrefix]).
+// Returns false if the link is invalid or its path does not start with the section.
+func LinkID(href, section string) (string, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if _, ok := LocaleByPrefix(parts[0]); ok {
+		parts = parts[1:]
+	}
+
+	if len(parts) < 2 || parts[0] != section || parts[1] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+// localizeURL returns copy of URL with path prefix of the locale. URL already prefixed with
+// registered locale is returned as is.
+func localizeURL(u *url.URL, locale Locale) *url.URL {
+	localized := *u
+
+	prefix := locale.PathPrefix()
+	if prefix == "" {
+		return &localized
+	}
+
+	first, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if _, ok := LocaleByPrefix(first); ok {
+		return &localized
+	}
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	localized.Path = ""
+	localized.RawPath = ""
+
+	return localized.JoinPath(prefix, path)
+}
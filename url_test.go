@@ -0,0 +1,219 @@
+package funpay_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+// TestMain registers locales used by tests. The locale registry is global (see [funpay.RegisterLocale]),
+// so it must not be changed by parallel tests.
+func TestMain(m *testing.M) {
+	funpay.RegisterLocale("uk", "uk")
+
+	os.Exit(m.Run())
+}
+
+func TestURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		builder  *funpay.URLBuilder
+		expected string
+	}{
+		"base url": {
+			builder:  funpay.URL(),
+			expected: "https://funpay.com",
+		},
+		"path with trailing slash": {
+			builder:  funpay.URL("users", "123", "/"),
+			expected: "https://funpay.com/users/123/",
+		},
+		"default locale": {
+			builder:  funpay.URL("lots", "offerEdit").WithLocale(funpay.LocaleRU).WithQuery("offer", "10"),
+			expected: "https://funpay.com/lots/offerEdit?offer=10",
+		},
+		"en locale": {
+			builder:  funpay.URL("chat", "/").WithLocale(funpay.LocaleEN),
+			expected: "https://funpay.com/en/chat/",
+		},
+		"registered locale with custom base url": {
+			builder:  funpay.URL("orders", "trade").WithBaseURL("http://127.0.0.1:8080").WithLocale("uk"),
+			expected: "http://127.0.0.1:8080/uk/orders/trade",
+		},
+		"locale of root": {
+			builder:  funpay.URL().WithLocale(funpay.LocaleEN).WithQuery("setlocale", "en"),
+			expected: "https://funpay.com/en/?setlocale=en",
+		},
+		"already prefixed path": {
+			builder:  funpay.URL("en", "lots", "/").WithLocale(funpay.LocaleEN),
+			expected: "https://funpay.com/en/lots/",
+		},
+		"invalid base url": {
+			builder:  funpay.URL("lots").WithBaseURL("http://[::1"),
+			expected: "",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if u := tt.builder.String(); u != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, u)
+			}
+		})
+	}
+}
+
+func TestLocale(t *testing.T) {
+	t.Parallel()
+
+	if !funpay.LocaleRU.Valid() || !funpay.LocaleEN.Valid() || funpay.Locale("xx").Valid() {
+		t.Error("unexpected locale validation")
+	}
+
+	if funpay.LocaleRU.PathPrefix() != "" || funpay.LocaleEN.PathPrefix() != "en" {
+		t.Error("unexpected locale prefixes")
+	}
+
+	if locale, ok := funpay.LocaleByPrefix("en"); !ok || locale != funpay.LocaleEN {
+		t.Errorf("expected LocaleEN, got %q", locale)
+	}
+
+	if _, ok := funpay.LocaleByPrefix(""); ok {
+		t.Error("empty prefix must not match default locale")
+	}
+}
+
+func TestLinkID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		href    string
+		section string
+		id      string
+		ok      bool
+	}{
+		"relative":          {href: "/lots/2852/", section: "lots", id: "2852", ok: true},
+		"absolute":          {href: "https://funpay.com/orders/ABCD/", section: "orders", id: "ABCD", ok: true},
+		"locale prefix":     {href: "https://funpay.com/uk/chips/10/", section: "chips", id: "10", ok: true},
+		"another section":   {href: "/users/1/", section: "lots", ok: false},
+		"section in middle": {href: "/en/users/lots/1/", section: "lots", ok: false},
+		"without id":        {href: "/orders/", section: "orders", ok: false},
+		"invalid":           {href: ":invalid", section: "lots", ok: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			id, ok := funpay.LinkID(tt.href, tt.section)
+			if id != tt.id || ok != tt.ok {
+				t.Errorf("expected %q, %v, got %q, %v", tt.id, tt.ok, id, ok)
+			}
+		})
+	}
+}
+
+func TestFunpay_Request_Locale(t *testing.T) {
+	t.Parallel()
+
+	var (
+		paths []string
+		mu    sync.Mutex
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		w.Write([]byte(`<html><body data-app-data='{"userId":1,"csrf-token":"test","locale":"en"}'></body></html>`))
+	}))
+	defer ts.Close()
+
+	fp := funpay.New("test_key", "test_agent")
+	fp.SetBaseURL(ts.URL)
+
+	if err := fp.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, opt := range []funpay.RequestOpt{funpay.RequestWithMethod(http.MethodGet), funpay.RequestWithMethod(http.MethodPost)} {
+		resp, err := fp.Request(t.Context(), ts.URL+"/lots/offerSave", opt)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := fp.Request(t.Context(), funpay.URL("en", "chat", "/").WithBaseURL(ts.URL).String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := []string{"/", "/en/lots/offerSave", "/lots/offerSave", "/en/chat/"}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected paths %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected paths %v, got %v", expected, paths)
+			break
+		}
+	}
+
+	if err := fp.UpdateLocale(t.Context(), "xx"); !errors.Is(err, funpay.ErrUnsupportedLocale) {
+		t.Errorf("expected ErrUnsupportedLocale, got %v", err)
+	}
+}
+
+func TestFunpay_RequestWithLocale(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := "ru"
+		if strings.HasPrefix(r.URL.Path, "/en/") {
+			locale = "en"
+		}
+
+		fmt.Fprintf(w, `<html><body data-app-data='{"userId":1,"csrf-token":"test","locale":%q}'><h1>%s</h1></body></html>`, locale, locale)
+	}))
+	defer ts.Close()
+
+	fp := funpay.New("test_key", "test_agent")
+	fp.SetBaseURL(ts.URL)
+
+	if err := fp.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	doc, err := fp.RequestHTML(t.Context(), ts.URL+"/lots/offerEdit", funpay.RequestWithLocale(funpay.LocaleEN))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if doc.Find("h1").Text() != "en" {
+		t.Errorf("expected page in en locale, got %q", doc.Find("h1").Text())
+	}
+
+	if fp.Locale() != funpay.LocaleRU || fp.AppData().Locale != funpay.LocaleRU {
+		t.Errorf("account locale must not be changed, got %q", fp.Locale())
+	}
+
+	if _, err := fp.Request(t.Context(), ts.URL, funpay.RequestWithLocale("xx")); !errors.Is(err, funpay.ErrUnsupportedLocale) {
+		t.Errorf("expected ErrUnsupportedLocale, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package funpay
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// UserInfo represents the account info parsed from pages (see [FunpayUser.Info]).
+type UserInfo struct {
+	// AvatarURL, UnreadChats and UnreadOrders are updated from the header of every page.
+	AvatarURL    string
+	UnreadChats  int
+	UnreadOrders int
+
+	// Online, Rating, ReviewsCount and Registered are updated only from the own profile page
+	// (/users/{id}/, see [FunpayUpdater.RefreshProfile] or lots.ByUser), zero until it is loaded.
+	// Registered is the date as shown on the page.
+	Online       bool
+	Rating       float64
+	ReviewsCount int
+	Registered   string
+}
+
+// profilePathRegexp matches path of profile page without locale prefix.
+var profilePathRegexp = regexp.MustCompile(`^/users/(\d+)/?$`)
+
+// profileUserID returns user ID from profile page URL. Returns 0 if URL is not a profile page.
+func profileUserID(u *url.URL) int64 {
+	if u == nil {
+		return 0
+	}
+
+	path := u.Path
+	first, rest, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if _, ok := LocaleByPrefix(first); ok {
+		path = "/" + rest
+	}
+
+	matches := profilePathRegexp.FindStringSubmatch(path)
+	if matches == nil {
+		return 0
+	}
+
+	userID, _ := strconv.ParseInt(matches[1], 10, 64)
+
+	return userID
+}
+
+// updateHeaderInfo updates info from the header of the page.
+func updateHeaderInfo(info *UserInfo, doc *goquery.Document) {
+	if avatar, ok := doc.Find(".user-link-photo img").First().Attr("src"); ok {
+		info.AvatarURL = avatar
+	}
+
+	info.UnreadChats = parseCount(doc.Find(".badge-chat").First().Text())
+	info.UnreadOrders = parseCount(doc.Find(".badge-trade").First().Text())
+}
+
+// updateProfileInfo updates info from the profile page (see [ParseProfile]).
+func updateProfileInfo(info *UserInfo, doc *goquery.Document) {
+	profile, ok := ParseProfile(doc)
+	if !ok {
+		return
+	}
+
+	info.Online = profile.Online
+	info.Rating = profile.Rating
+	info.ReviewsCount = profile.ReviewsCount
+	info.Registered = profile.Registered
+}
+
+// parseCount returns the first number in the text ignoring spaces, e.g. 1234 from "1 234 отзыва". Returns 0 if there is no number.
+func parseCount(text string) int {
+	text = strings.NewReplacer(" ", "", "\u00a0", "").Replace(text)
+	digits := strings.FieldsFunc(text, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if len(digits) == 0 {
+		return 0
+	}
+
+	count, _ := strconv.Atoi(digits[0])
+
+	return count
+}
+
+// parseRating parses rating with dot or comma separator. Returns 0 if the text is not a number.
+func parseRating(text string) float64 {
+	rating, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(text), ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+
+	return rating
+}
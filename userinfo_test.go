@@ -0,0 +1,147 @@
+package funpay_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+const userInfoHeader = `
+	<header>
+		<div class="user-link-photo"><img src="https://funpay.com/img/avatar.jpg"></div>
+		<div class="user-link-name">testuser</div>
+		<span class="badge badge-chat">3</span>
+		<span class="badge badge-trade">1</span>
+		<span class="badge badge-balance">100 ₽</span>
+	</header>`
+
+const userInfoProfile = `
+	<div class="profile-header">
+		<div class="media media-user online"></div>
+	</div>
+	<div class="rating-value"><span class="big">4,9</span></div>
+	<div class="rating-full-count">Всего 1 234 отзыва</div>
+	<div class="param-item"><h5>Дата регистрации</h5><div class="text-nowrap">12 марта 2020, 15:00</div></div>`
+
+func TestFunpay_Info(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := userInfoHeader
+		if r.URL.Path == "/users/123/" || r.URL.Path == "/users/456/" {
+			body += userInfoProfile
+		}
+
+		fmt.Fprintf(w, `<html><body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru","currency":"RUB","timezone":"Europe/Moscow","webpush":{"app":"test","enabled":true,"hwid-required":false},"unknown":1}'>%s</body></html>`, body)
+	}))
+	defer ts.Close()
+
+	fp := funpay.New("test_key", "test_agent")
+	fp.SetBaseURL(ts.URL)
+
+	if err := fp.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	appData := fp.AppData()
+	if appData.Currency != funpay.CurrencyRUB || appData.Timezone != "Europe/Moscow" || appData.Webpush != (funpay.Webpush{App: "test", Enabled: true}) {
+		t.Errorf("unexpected app data: %+v", appData)
+	}
+
+	if string(appData.Raw["unknown"]) != "1" {
+		t.Errorf("expected unknown field in raw app data, got %v", appData.Raw)
+	}
+
+	header := funpay.UserInfo{AvatarURL: "https://funpay.com/img/avatar.jpg", UnreadChats: 3, UnreadOrders: 1}
+	if info := fp.Info(); !reflect.DeepEqual(info, header) {
+		t.Errorf("expected %+v, got %+v", header, info)
+	}
+
+	if _, err := fp.RequestHTML(t.Context(), ts.URL+"/users/456/"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info := fp.Info(); !reflect.DeepEqual(info, header) {
+		t.Errorf("profile of another user must not update info, got %+v", info)
+	}
+
+	if err := fp.RefreshProfile(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := header
+	expected.Online = true
+	expected.Rating = 4.9
+	expected.ReviewsCount = 1234
+	expected.Registered = "12 марта 2020, 15:00"
+	if info := fp.Info(); !reflect.DeepEqual(info, expected) {
+		t.Errorf("expected %+v, got %+v", expected, info)
+	}
+}
+
+func TestFunpay_RefreshProfile(t *testing.T) {
+	t.Parallel()
+	t.Run("registered locale prefix", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body := userInfoHeader
+			if r.URL.Path == "/uk/users/123/" {
+				body += userInfoProfile
+			}
+
+			fmt.Fprintf(w, `<html><body data-app-data='{"userId":123,"csrf-token":"test","locale":"uk"}'>%s</body></html>`, body)
+		}))
+		defer ts.Close()
+
+		fp := funpay.New("test_key", "test_agent")
+		fp.SetBaseURL(ts.URL)
+
+		if err := fp.Update(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := fp.RefreshProfile(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if info := fp.Info(); info.ReviewsCount != 1234 || !info.Online {
+			t.Errorf("expected profile info, got %+v", info)
+		}
+	})
+
+	t.Run("not updated", func(t *testing.T) {
+		t.Parallel()
+
+		fp := funpay.New("test_key", "test_agent")
+
+		if err := fp.RefreshProfile(t.Context()); !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Errorf("expected ErrAccountUnauthorized, got %v", err)
+		}
+	})
+}
+
+func TestAppData_NonStringFields(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru","currency":1,"timezone":180}'></body></html>`)
+	}))
+	defer ts.Close()
+
+	fp := funpay.New("test_key", "test_agent")
+	fp.SetBaseURL(ts.URL)
+
+	if err := fp.Update(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if appData := fp.AppData(); appData.Currency != "" || appData.Timezone != "" || appData.UserID != 123 {
+		t.Errorf("unexpected app data: %+v", appData)
+	}
+}
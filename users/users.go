@@ -0,0 +1,79 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/lots"
+)
+
+var (
+	// ErrUserNotFound indicates that user profile page does not exist.
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// User represents public profile of the user (/users/{id}/).
+type User struct {
+	ID int64
+
+	funpay.Profile
+
+	// Offers contains offers of the user. Key represents nodeID, value represents slice of offerIDs (see [lots.ExtractLots]).
+	Offers map[lots.NodeID][]lots.OfferID
+}
+
+//go:generate go tool mockgen -destination ../mocks/users.go -package mocks . Users
+type Users interface {
+	// Get loads public profile of the user.
+	// Returns [ErrUserNotFound] if profile page does not exist.
+	Get(ctx context.Context, userID int64) (User, error)
+}
+
+type UsersClient struct {
+	fp funpay.Funpay
+}
+
+func New(fp funpay.Funpay) Users {
+	return &UsersClient{
+		fp: fp,
+	}
+}
+
+func (u *UsersClient) Get(ctx context.Context, userID int64) (User, error) {
+	const op = "UsersClient.Get"
+
+	reqURL, err := funpay.URL("users", strconv.FormatInt(userID, 10), "/").WithBaseURL(u.fp.BaseURL()).Parse()
+	if err != nil {
+		return User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc, err := u.fp.RequestHTML(ctx, reqURL.String())
+	if err != nil {
+		var httpErr *funpay.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return User{}, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+
+		return User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	profile, ok := funpay.ParseProfile(doc)
+	if !ok {
+		return User{}, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+
+	offers, err := lots.ExtractLots(doc)
+	if err != nil {
+		return User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return User{
+		ID:      userID,
+		Profile: profile,
+		Offers:  offers,
+	}, nil
+}
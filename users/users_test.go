@@ -0,0 +1,172 @@
+package users_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kostromin59/funpay"
+	"github.com/kostromin59/funpay/lots"
+	"github.com/kostromin59/funpay/mocks"
+	"github.com/kostromin59/funpay/users"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsers_Get(t *testing.T) {
+	t.Parallel()
+	t.Run("successful profile retrieval", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpUsers := users.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+			<body data-app-data='{"userId":1,"csrf-token":"test","locale":"ru"}'>
+				<div class="profile-header">
+					<div class="avatar-photo" style="background-image: url(https://funpay.com/img/25.jpg);"></div>
+					<div class="media media-user offline">
+						<div class="media-user-name"><span class="mr4">competitor</span> <span class="label label-danger">заблокирован</span></div>
+						<div class="media-user-status">Был 5 минут назад</div>
+					</div>
+				</div>
+				<div class="param-item"><h5>Дата регистрации</h5><div class="text-nowrap">12 марта 2020, 15:00</div></div>
+				<div class="rating-value"><span class="big">4.8</span></div>
+				<div class="rating-full-count">Всего 56 отзывов</div>
+				<div class="offer">
+					<div class="offer-list-title"><h3><a href="https://funpay.com/lots/2852/">Steam</a></h3></div>
+					<a href="https://funpay.com/lots/offer?id=10" class="tc-item"></a>
+					<a href="https://funpay.com/lots/offer?id=11" class="tc-item"></a>
+				</div>
+			</body>
+		</html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/users/25/").Times(1).Return(doc, nil)
+
+		user, err := fpUsers.Get(t.Context(), 25)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := users.User{
+			ID: 25,
+			Profile: funpay.Profile{
+				Username:     "competitor",
+				AvatarURL:    "https://funpay.com/img/25.jpg",
+				LastSeen:     "Был 5 минут назад",
+				Registered:   "12 марта 2020, 15:00",
+				Rating:       4.8,
+				ReviewsCount: 56,
+				Banned:       true,
+			},
+			Offers: map[lots.NodeID][]lots.OfferID{"2852": {"10", "11"}},
+		}
+
+		if !reflect.DeepEqual(user, expected) {
+			t.Errorf("expected %+v, got %+v", expected, user)
+		}
+	})
+
+	t.Run("online user without offers", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpUsers := users.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+			<body>
+				<div class="profile-header">
+					<div class="media media-user online">
+						<div class="media-user-name">buyer</div>
+						<div class="media-user-status">Онлайн</div>
+					</div>
+				</div>
+			</body>
+		</html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/users/26/").Times(1).Return(doc, nil)
+
+		user, err := fpUsers.Get(t.Context(), 26)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if user.Username != "buyer" || !user.Online || user.LastSeen != "" || user.Banned || len(user.Offers) != 0 {
+			t.Errorf("unexpected user: %+v", user)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpUsers := users.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/users/27/").Times(1).Return(nil, &funpay.HTTPError{StatusCode: 404, Err: funpay.ErrBadStatusCode})
+
+		_, err := fpUsers.Get(t.Context(), 27)
+		if !errors.Is(err, users.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("not a profile page", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpUsers := users.New(fp)
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><body></body></html>`))
+		if err != nil {
+			t.Fatal("invalid doc provided")
+		}
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/users/28/").Times(1).Return(doc, nil)
+
+		_, err = fpUsers.Get(t.Context(), 28)
+		if !errors.Is(err, users.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fp := mocks.NewMockFunpay(ctrl)
+		fpUsers := users.New(fp)
+
+		fp.EXPECT().BaseURL().Times(1).Return("https://funpay.com")
+		fp.EXPECT().RequestHTML(t.Context(), "https://funpay.com/users/29/").Times(1).Return(nil, funpay.ErrTooManyRequests)
+
+		_, err := fpUsers.Get(t.Context(), 29)
+		if !errors.Is(err, funpay.ErrTooManyRequests) {
+			t.Errorf("expected ErrTooManyRequests, got %v", err)
+		}
+	})
+}
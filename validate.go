@@ -0,0 +1,102 @@
+package funpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// ErrAccountBanned indicates that golden key is valid, but the account is blocked by Funpay.
+	ErrAccountBanned = errors.New("account banned")
+)
+
+// banMarkers are lowercased texts of the notice shown to blocked accounts.
+var banMarkers = []string{
+	"аккаунт заблокирован",
+	"account has been blocked",
+	"account is blocked",
+	"account has been banned",
+}
+
+// AccountInfo represents the account data returned by [Validate].
+type AccountInfo struct {
+	UserID   int64
+	Username string
+	Locale   Locale
+}
+
+// ValidateOpts contains configurable parameters for [Validate].
+type ValidateOpts struct {
+	baseURL string
+	proxy   *url.URL
+}
+
+// ValidateOpt defines a function type for modifying validate options.
+type ValidateOpt func(opts *ValidateOpts)
+
+// ValidateWithProxy sets the proxy for the validation request (see [FunpayRequester.SetProxy]).
+func ValidateWithProxy(proxy *url.URL) ValidateOpt {
+	return func(opts *ValidateOpts) {
+		opts.proxy = proxy
+	}
+}
+
+// ValidateWithBaseURL sets base URL. Needed for tests to substitute the [BaseURL] with test server.
+// Default: [BaseURL]
+func ValidateWithBaseURL(baseURL string) ValidateOpt {
+	return func(opts *ValidateOpts) {
+		opts.baseURL = baseURL
+	}
+}
+
+// Validate checks golden key before storing it: requests the main page with a new client
+// and returns account data.
+//
+// Specific returns:
+//   - [ErrAccountUnauthorized] if golden key is empty or invalid,
+//   - [ErrAccountBanned] if the account is blocked,
+//   - [ErrChallenge], [ErrCaptcha] or [ErrMaintenance] if Funpay page cannot be loaded (see [FunpayRequester.RequestHTML]).
+func Validate(ctx context.Context, goldenKey, userAgent string, opts ...ValidateOpt) (AccountInfo, error) {
+	const op = "Validate"
+
+	if strings.TrimSpace(goldenKey) == "" {
+		return AccountInfo{}, fmt.Errorf("%s: %w", op, ErrAccountUnauthorized)
+	}
+
+	validateOpts := &ValidateOpts{
+		baseURL: BaseURL,
+	}
+	for _, opt := range opts {
+		opt(validateOpts)
+	}
+
+	fp := New(goldenKey, userAgent)
+	fp.SetBaseURL(validateOpts.baseURL)
+	fp.SetProxy(validateOpts.proxy)
+
+	doc, err := fp.RequestHTML(ctx, fp.BaseURL())
+	if err != nil {
+		return AccountInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if isBanned(doc) {
+		return AccountInfo{}, fmt.Errorf("%s: %w", op, ErrAccountBanned)
+	}
+
+	return AccountInfo{
+		UserID:   fp.UserID(),
+		Username: fp.Username(),
+		Locale:   fp.Locale(),
+	}, nil
+}
+
+// isBanned reports whether the page contains notice of blocked account.
+func isBanned(doc *goquery.Document) bool {
+	text := strings.ToLower(doc.Find(".alert, .page-header, h1").Text())
+	return containsAny(text, banMarkers)
+}
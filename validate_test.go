@@ -0,0 +1,87 @@
+package funpay_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kostromin59/funpay"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status   int
+		body     string
+		expected error
+	}{
+		"valid": {
+			status: http.StatusOK,
+			body: `<html><body data-app-data='{"userId":123,"csrf-token":"test","locale":"en"}'>
+				<div class="user-link-name">testuser</div>
+			</body></html>`,
+		},
+		"unauthorized": {
+			status:   http.StatusOK,
+			body:     `<html><body data-app-data='{"userId":0,"csrf-token":"test","locale":"ru"}'></body></html>`,
+			expected: funpay.ErrAccountUnauthorized,
+		},
+		"banned": {
+			status: http.StatusOK,
+			body: `<html><body data-app-data='{"userId":123,"csrf-token":"test","locale":"ru"}'>
+				<div class="alert alert-danger">Ваш аккаунт заблокирован.</div>
+			</body></html>`,
+			expected: funpay.ErrAccountBanned,
+		},
+		"challenge": {
+			status:   http.StatusForbidden,
+			body:     `<html><head><title>Just a moment...</title></head><body></body></html>`,
+			expected: funpay.ErrChallenge,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if cookie, err := r.Cookie(funpay.CookieGoldenKey); err != nil || cookie.Value != "test_key" {
+					t.Errorf("expected golden key cookie, got %v", cookie)
+				}
+
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer ts.Close()
+
+			info, err := funpay.Validate(t.Context(), "test_key", "test_agent", funpay.ValidateWithBaseURL(ts.URL))
+			if tt.expected != nil {
+				if !errors.Is(err, tt.expected) {
+					t.Fatalf("expected %v, got %v", tt.expected, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := funpay.AccountInfo{UserID: 123, Username: "testuser", Locale: funpay.LocaleEN}
+			if info != expected {
+				t.Errorf("expected %+v, got %+v", expected, info)
+			}
+		})
+	}
+
+	t.Run("empty golden key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := funpay.Validate(t.Context(), " ", "test_agent")
+		if !errors.Is(err, funpay.ErrAccountUnauthorized) {
+			t.Errorf("expected ErrAccountUnauthorized, got %v", err)
+		}
+	})
+}